@@ -0,0 +1,67 @@
+//go:build go1.18
+// +build go1.18
+
+package zarray
+
+// DisjointSet union-find with path compression and union by rank, not safe for concurrent use
+type DisjointSet[T comparable] struct {
+	parent map[T]T
+	rank   map[T]int
+}
+
+// NewDisjointSet create a disjoint set
+func NewDisjointSet[T comparable]() *DisjointSet[T] {
+	return &DisjointSet[T]{
+		parent: make(map[T]T),
+		rank:   make(map[T]int),
+	}
+}
+
+// MakeSet add the item as a single element set, existing items are left untouched
+func (d *DisjointSet[T]) MakeSet(item T) {
+	if _, ok := d.parent[item]; ok {
+		return
+	}
+	d.parent[item] = item
+	d.rank[item] = 0
+}
+
+// Find returns the root of the set containing item, unknown items become their own set
+func (d *DisjointSet[T]) Find(item T) T {
+	p, ok := d.parent[item]
+	if !ok {
+		d.MakeSet(item)
+		return item
+	}
+
+	if p == item {
+		return item
+	}
+
+	root := d.Find(p)
+	d.parent[item] = root
+	return root
+}
+
+// Union merge the sets containing a and b
+func (d *DisjointSet[T]) Union(a, b T) {
+	ra, rb := d.Find(a), d.Find(b)
+	if ra == rb {
+		return
+	}
+
+	switch {
+	case d.rank[ra] < d.rank[rb]:
+		d.parent[ra] = rb
+	case d.rank[ra] > d.rank[rb]:
+		d.parent[rb] = ra
+	default:
+		d.parent[rb] = ra
+		d.rank[ra]++
+	}
+}
+
+// Connected returns true if a and b are in the same set
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	return d.Find(a) == d.Find(b)
+}
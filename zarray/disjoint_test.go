@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+package zarray_test
+
+import (
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+	"github.com/sohaha/zlsgo/zarray"
+)
+
+func TestDisjointSet(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	d := zarray.NewDisjointSet[int]()
+	d.MakeSet(1)
+	tt.Equal(1, d.Find(1))
+	tt.EqualTrue(d.Connected(1, 1))
+
+	for i := 2; i <= 6; i++ {
+		d.MakeSet(i)
+	}
+	tt.EqualTrue(!d.Connected(1, 2))
+
+	d.Union(1, 2)
+	tt.EqualTrue(d.Connected(1, 2))
+	tt.Equal(d.Find(1), d.Find(2))
+
+	d.Union(3, 4)
+	d.Union(4, 5)
+	tt.EqualTrue(d.Connected(3, 5))
+	tt.EqualTrue(!d.Connected(1, 3))
+
+	d.Union(2, 5)
+	root := d.Find(1)
+	for i := 1; i <= 5; i++ {
+		tt.Equal(root, d.Find(i))
+	}
+	tt.EqualTrue(!d.Connected(1, 6))
+	tt.Equal(6, d.Find(6))
+
+	d.MakeSet(1)
+	tt.EqualTrue(d.Connected(1, 5))
+
+	s := zarray.NewDisjointSet[string]()
+	tt.Equal("a", s.Find("a"))
+	s.Union("a", "b")
+	tt.EqualTrue(s.Connected("b", "a"))
+	tt.EqualTrue(!s.Connected("a", "c"))
+}
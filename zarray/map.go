@@ -3,6 +3,12 @@
 
 package zarray
 
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
 // Keys creates an array of the map keys
 func Keys[K comparable, V any](in map[K]V) []K {
 	result := make([]K, 0, len(in))
@@ -24,3 +30,46 @@ func Values[K comparable, V any](in map[K]V) []V {
 
 	return result
 }
+
+// MapToSlice creates an array from the transformed map entries, the order is not specified
+func MapToSlice[K comparable, V any, R any](in map[K]V, transform func(K, V) R) []R {
+	result := make([]R, 0, len(in))
+
+	for k, v := range in {
+		result = append(result, transform(k, v))
+	}
+
+	return result
+}
+
+// ToSet creates a set from the slice, duplicates are collapsed
+func ToSet[T comparable](collection []T) map[T]struct{} {
+	result := make(map[T]struct{}, len(collection))
+
+	for i := range collection {
+		result[collection[i]] = struct{}{}
+	}
+
+	return result
+}
+
+// FromSet creates an array of the set elements, the order is not specified
+func FromSet[T comparable](set map[T]struct{}) []T {
+	result := make([]T, 0, len(set))
+
+	for k := range set {
+		result = append(result, k)
+	}
+
+	return result
+}
+
+// FromSetSorted creates a sorted array of the set elements
+func FromSetSorted[T constraints.Ordered](set map[T]struct{}) []T {
+	result := FromSet(set)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+
+	return result
+}
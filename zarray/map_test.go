@@ -4,6 +4,8 @@
 package zarray
 
 import (
+	"sort"
+	"strconv"
 	"testing"
 
 	"github.com/sohaha/zlsgo"
@@ -20,3 +22,56 @@ func TestValues(t *testing.T) {
 	tt.Equal(3, len(Values(map[int]int{1: 1, 2: 2, 3: 3})))
 	tt.Equal(3, len(Values(map[int]interface{}{1: 1, 2: "2", 3: 3})))
 }
+
+func TestMapToSlice(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	type pair struct {
+		Key   string
+		Value int
+	}
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	pairs := MapToSlice(m, func(k string, v int) pair {
+		return pair{k, v}
+	})
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	tt.Equal([]pair{{"a", 1}, {"b", 2}, {"c", 3}}, pairs)
+
+	strs := MapToSlice(m, func(k string, v int) string {
+		return k + "=" + strconv.Itoa(v)
+	})
+	sort.Strings(strs)
+	tt.Equal([]string{"a=1", "b=2", "c=3"}, strs)
+
+	sum := MapToSlice(m, func(_ string, v int) float64 {
+		return float64(v) / 2
+	})
+	tt.Equal(3, len(sum))
+
+	tt.Equal([]string{}, MapToSlice(map[string]int{}, func(k string, _ int) string { return k }))
+}
+
+func TestToSetFromSet(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	set := ToSet([]string{"b", "a", "c", "a", "b"})
+	tt.Equal(3, len(set))
+	for _, v := range []string{"a", "b", "c"} {
+		_, ok := set[v]
+		tt.EqualTrue(ok)
+	}
+
+	tt.Equal([]string{"a", "b", "c"}, FromSetSorted(set))
+	tt.Equal([]int{1, 2, 3}, FromSetSorted(ToSet([]int{3, 1, 2, 3})))
+	tt.Equal([]int{}, FromSetSorted(ToSet([]int{})))
+
+	type point struct{ X, Y int }
+	points := FromSet(ToSet([]point{{1, 2}, {3, 4}, {1, 2}}))
+	tt.Equal(2, len(points))
+	back := ToSet(points)
+	_, ok := back[point{1, 2}]
+	tt.EqualTrue(ok)
+	_, ok = back[point{3, 4}]
+	tt.EqualTrue(ok)
+	tt.Equal([]point{}, FromSet(map[point]struct{}{}))
+}
@@ -4,9 +4,14 @@
 package zarray
 
 import (
+	"errors"
 	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
 
 	"github.com/sohaha/zlsgo/zstring"
+	"golang.org/x/exp/constraints"
 )
 
 // CopySlice copy a slice
@@ -16,6 +21,39 @@ func CopySlice[T any](l []T) []T {
 	return nl
 }
 
+// Generate create a slice of length n with fn called for each index
+func Generate[T any](n int, fn func(index int) T) []T {
+	if n <= 0 {
+		return []T{}
+	}
+
+	res := make([]T, n)
+	for i := range res {
+		res[i] = fn(i)
+	}
+
+	return res
+}
+
+// First returns the first element of collection, ok is false for an empty slice
+func First[T any](collection []T) (res T, ok bool) {
+	if len(collection) == 0 {
+		return
+	}
+
+	return collection[0], true
+}
+
+// Last returns the last element of collection, ok is false for an empty slice
+func Last[T any](collection []T) (res T, ok bool) {
+	l := len(collection)
+	if l == 0 {
+		return
+	}
+
+	return collection[l-1], true
+}
+
 // Rand A random eents
 func Rand[T any](collection []T) T {
 	l := len(collection)
@@ -39,12 +77,140 @@ func Map[T any, R any](collection []T, iteratee func(int, T) R) []R {
 	return res
 }
 
+// MapConcurrent manipulates a slice with up to concurrency goroutines,
+// results keep the input order and a panic in iteratee is re-raised in the caller
+func MapConcurrent[T any, R any](collection []T, concurrency int, iteratee func(int, T) R) []R {
+	res := make([]R, len(collection))
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		panicErr interface{}
+		sem      = make(chan struct{}, concurrency)
+	)
+	for i := range collection {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { panicErr = r })
+				}
+				<-sem
+				wg.Done()
+			}()
+			res[i] = iteratee(i, collection[i])
+		}(i)
+	}
+	wg.Wait()
+
+	if panicErr != nil {
+		panic(panicErr)
+	}
+
+	return res
+}
+
+// ParallelMap is MapConcurrent with workers <= 0 defaulting to runtime.NumCPU()
+func ParallelMap[T any, R any](collection []T, iteratee func(int, T) R, workers int) []R {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return MapConcurrent(collection, workers, iteratee)
+}
+
+// MapError manipulates a slice with a fallible iteratee,
+// the first error stops iteration and is returned with the results so far
+func MapError[T any, R any](collection []T, iteratee func(int, T) (R, error)) ([]R, error) {
+	if len(collection) == 0 {
+		return nil, nil
+	}
+
+	res := make([]R, 0, len(collection))
+	for i, item := range collection {
+		r, err := iteratee(i, item)
+		if err != nil {
+			return res, err
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+// MapFilterError transforms and filters a slice in one pass,
+// the first error stops iteration and is returned with the results so far
+func MapFilterError[T any, R any](collection []T, iteratee func(int, T) (R, bool, error)) ([]R, error) {
+	res := make([]R, 0, len(collection))
+
+	for i, item := range collection {
+		r, ok, err := iteratee(i, item)
+		if err != nil {
+			return res, err
+		}
+		if ok {
+			res = append(res, r)
+		}
+	}
+
+	return res, nil
+}
+
+// Flatten concatenates a slice of slices into a single slice
+func Flatten[T any](collection [][]T) []T {
+	l := 0
+	for i := range collection {
+		l += len(collection[i])
+	}
+
+	res := make([]T, 0, l)
+	for i := range collection {
+		res = append(res, collection[i]...)
+	}
+
+	return res
+}
+
+// FlatMap manipulates a slice and flattens the slices returned by iteratee
+func FlatMap[T any, R any](collection []T, iteratee func(int, T) []R) []R {
+	parts := make([][]R, len(collection))
+	for i, item := range collection {
+		parts[i] = iteratee(i, item)
+	}
+
+	return Flatten(parts)
+}
+
+// Reduce fold a slice into a single value, initial is returned for an empty slice
+func Reduce[T any, R any](collection []T, initial R, iteratee func(acc R, index int, item T) R) R {
+	acc := initial
+	for i, item := range collection {
+		acc = iteratee(acc, i, item)
+	}
+
+	return acc
+}
+
 // Shuffle creates a slice of shuffled values
 func Shuffle[T any](collection []T) []T {
+	return ShuffleWithRand(collection, nil)
+}
+
+// ShuffleWithRand creates a slice of shuffled values using r as the random source,
+// a nil r uses the package-level source
+func ShuffleWithRand[T any](collection []T, r *rand.Rand) []T {
 	n := CopySlice(collection)
-	rand.Shuffle(len(n), func(i, j int) {
+	swap := func(i, j int) {
 		n[i], n[j] = n[j], n[i]
-	})
+	}
+	if r == nil {
+		rand.Shuffle(len(n), swap)
+	} else {
+		r.Shuffle(len(n), swap)
+	}
 
 	return n
 }
@@ -60,6 +226,29 @@ func Reverse[T any](collection []T) []T {
 	return n
 }
 
+// SortStableBy creates a sorted copy of collection,
+// elements that compare equal keep their original order
+func SortStableBy[T any](collection []T, less func(a, b T) bool) []T {
+	n := CopySlice(collection)
+	sort.SliceStable(n, func(i, j int) bool {
+		return less(n[i], n[j])
+	})
+
+	return n
+}
+
+// SortBy creates a stably sorted copy of collection, same as SortStableBy
+func SortBy[T any](collection []T, less func(a, b T) bool) []T {
+	return SortStableBy(collection, less)
+}
+
+// SortByField creates a stably sorted copy of collection ordered by the ascending value of field
+func SortByField[T any, K constraints.Ordered](collection []T, field func(T) K) []T {
+	return SortStableBy(collection, func(a, b T) bool {
+		return field(a) < field(b)
+	})
+}
+
 // Filter iterates over eents of collection
 func Filter[T any](slice []T, predicate func(index int, item T) bool) []T {
 	slice = CopySlice(slice)
@@ -76,6 +265,15 @@ func Filter[T any](slice []T, predicate func(index int, item T) bool) []T {
 	return slice[:j:j]
 }
 
+// ForEach iterates over elements of collection, returning false from iteratee stops the iteration
+func ForEach[T any](collection []T, iteratee func(index int, item T) bool) {
+	for i := range collection {
+		if !iteratee(i, collection[i]) {
+			return
+		}
+	}
+}
+
 // Contains returns true if an eent is present in a collection
 func Contains[T comparable](collection []T, v T) bool {
 	for _, item := range collection {
@@ -87,6 +285,30 @@ func Contains[T comparable](collection []T, v T) bool {
 	return false
 }
 
+// Count returns the number of times v appears in collection
+func Count[T comparable](collection []T, v T) int {
+	n := 0
+	for _, item := range collection {
+		if item == v {
+			n++
+		}
+	}
+
+	return n
+}
+
+// CountBy returns the number of elements matching predicate
+func CountBy[T any](collection []T, predicate func(index int, item T) bool) int {
+	n := 0
+	for i := range collection {
+		if predicate(i, collection[i]) {
+			n++
+		}
+	}
+
+	return n
+}
+
 // Find search an eent in a slice based on a predicate. It returns eent and true if eent was found.
 func Find[T any](collection []T, predicate func(index int, item T) bool) (res T, ok bool) {
 	for i := range collection {
@@ -99,6 +321,44 @@ func Find[T any](collection []T, predicate func(index int, item T) bool) (res T,
 	return
 }
 
+// Every reports whether predicate holds for every element, stopping at the first false,
+// an empty slice returns true
+func Every[T any](collection []T, predicate func(index int, item T) bool) bool {
+	for i := range collection {
+		if !predicate(i, collection[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ZipN groups the elements at the same index of each collection,
+// the result is as long as the shortest collection, use Zip to pair two slices of different types
+func ZipN[T any](collections ...[]T) [][]T {
+	if len(collections) == 0 {
+		return [][]T{}
+	}
+
+	size := len(collections[0])
+	for i := range collections {
+		if l := len(collections[i]); l < size {
+			size = l
+		}
+	}
+
+	res := make([][]T, size)
+	for i := range res {
+		tuple := make([]T, len(collections))
+		for j := range collections {
+			tuple[j] = collections[j][i]
+		}
+		res[i] = tuple
+	}
+
+	return res
+}
+
 // Unique returns a duplicate-free version of an array
 func Unique[T comparable](collection []T) []T {
 	repeat := make(map[T]struct{}, len(collection))
@@ -112,6 +372,20 @@ func Unique[T comparable](collection []T) []T {
 	})
 }
 
+// UniqueBy returns a duplicate-free version of an array, using keyFn to identify duplicates
+func UniqueBy[T any, K comparable](collection []T, keyFn func(T) K) []T {
+	repeat := make(map[K]struct{}, len(collection))
+
+	return Filter(collection, func(_ int, item T) bool {
+		k := keyFn(item)
+		if _, ok := repeat[k]; ok {
+			return false
+		}
+		repeat[k] = struct{}{}
+		return true
+	})
+}
+
 func Diff[T comparable](list1 []T, list2 []T) ([]T, []T) {
 	l, r := []T{}, []T{}
 
@@ -140,6 +414,31 @@ func Diff[T comparable](list1 []T, list2 []T) ([]T, []T) {
 	return l, r
 }
 
+// Intersect returns the elements present in both lists without duplicates,
+// in the order they appear in list1
+func Intersect[T comparable](list1 []T, list2 []T) []T {
+	res := []T{}
+
+	rr := make(map[T]struct{}, len(list2))
+	for _, e := range list2 {
+		rr[e] = struct{}{}
+	}
+
+	seen := make(map[T]struct{}, len(list1))
+	for _, e := range list1 {
+		if _, ok := rr[e]; !ok {
+			continue
+		}
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		res = append(res, e)
+	}
+
+	return res
+}
+
 func Pop[T comparable](list *[]T) (v T) {
 	l := len(*list)
 	if l == 0 {
@@ -161,3 +460,242 @@ func Shift[T comparable](list *[]T) (v T) {
 	*list = (*list)[1:]
 	return
 }
+
+// EveryNth returns every nth element starting from index 0
+func EveryNth[T any](collection []T, n int) ([]T, error) {
+	if n <= 0 {
+		return nil, errors.New("n must be greater than 0")
+	}
+
+	res := make([]T, 0, (len(collection)+n-1)/n)
+	for i := 0; i < len(collection); i += n {
+		res = append(res, collection[i])
+	}
+
+	return res, nil
+}
+
+// PartitionN split a slice into n parts as evenly as possible, the first len%n parts get one extra element,
+// n greater than the length gives one element per part, n <= 0 returns nil
+func PartitionN[T any](collection []T, n int) [][]T {
+	if n <= 0 {
+		return nil
+	}
+
+	l := len(collection)
+	if n > l {
+		n = l
+	}
+	res := make([][]T, 0, n)
+	size, extra := 0, 0
+	if n > 0 {
+		size, extra = l/n, l%n
+	}
+	for i, start := 0, 0; i < n; i++ {
+		end := start + size
+		if i < extra {
+			end++
+		}
+		res = append(res, collection[start:end:end])
+		start = end
+	}
+
+	return res
+}
+
+// Chunk split a slice into chunks of size, the last chunk holds the remainder
+func Chunk[T any](collection []T, size int) ([][]T, error) {
+	return ChunkOverlap(collection, size, 0)
+}
+
+// ChunkOverlap split a slice into chunks of size, consecutive chunks share overlap elements
+func ChunkOverlap[T any](collection []T, size, overlap int) ([][]T, error) {
+	if size <= 0 {
+		return nil, errors.New("size must be greater than 0")
+	}
+	if overlap < 0 || overlap >= size {
+		return nil, errors.New("overlap must be greater than or equal to 0 and less than size")
+	}
+
+	l := len(collection)
+	step := size - overlap
+	res := make([][]T, 0, (l+step-1)/step)
+	for i := 0; i < l; i += step {
+		end := i + size
+		if end > l {
+			end = l
+		}
+		res = append(res, collection[i:end:end])
+		if end == l {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// Diff3 three-way merge of ours and theirs against base by position,
+// conflicting positions keep the base value and append ours then theirs to conflicts
+func Diff3[T comparable](base, ours, theirs []T) (merged []T, conflicts []T) {
+	l := len(base)
+	if len(ours) > l {
+		l = len(ours)
+	}
+	if len(theirs) > l {
+		l = len(theirs)
+	}
+
+	at := func(list []T, i int) (v T, ok bool) {
+		if i < len(list) {
+			return list[i], true
+		}
+		return
+	}
+
+	merged, conflicts = make([]T, 0, l), []T{}
+	for i := 0; i < l; i++ {
+		b, bok := at(base, i)
+		o, ook := at(ours, i)
+		t, tok := at(theirs, i)
+
+		switch {
+		case ook == bok && o == b:
+			if tok {
+				merged = append(merged, t)
+			}
+		case tok == bok && t == b, tok == ook && t == o:
+			if ook {
+				merged = append(merged, o)
+			}
+		default:
+			if bok {
+				merged = append(merged, b)
+			}
+			if ook {
+				conflicts = append(conflicts, o)
+			}
+			if tok {
+				conflicts = append(conflicts, t)
+			}
+		}
+	}
+
+	return
+}
+
+// GroupBy groups the elements of a slice by the key returned from keyFn,
+// elements keep their original order within each group
+func GroupBy[T any, K comparable](collection []T, keyFn func(int, T) K) map[K][]T {
+	res := make(map[K][]T)
+	for i, item := range collection {
+		k := keyFn(i, item)
+		res[k] = append(res[k], item)
+	}
+
+	return res
+}
+
+// Pair holds two values of possibly different types
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip pairs up the elements of a and b, up to the length of the shorter one
+func Zip[T any, U any](a []T, b []U) []Pair[T, U] {
+	l := len(a)
+	if len(b) < l {
+		l = len(b)
+	}
+
+	res := make([]Pair[T, U], l)
+	for i := 0; i < l; i++ {
+		res[i] = Pair[T, U]{First: a[i], Second: b[i]}
+	}
+
+	return res
+}
+
+// Unzip splits pairs back into two slices, the inverse of Zip
+func Unzip[T any, U any](pairs []Pair[T, U]) ([]T, []U) {
+	a, b := make([]T, len(pairs)), make([]U, len(pairs))
+	for i := range pairs {
+		a[i], b[i] = pairs[i].First, pairs[i].Second
+	}
+
+	return a, b
+}
+
+// Min returns the smallest element, ok is false for an empty slice
+func Min[T constraints.Ordered](collection []T) (res T, ok bool) {
+	return MinBy(collection, func(v T) T { return v })
+}
+
+// Max returns the largest element, ok is false for an empty slice
+func Max[T constraints.Ordered](collection []T) (res T, ok bool) {
+	return MaxBy(collection, func(v T) T { return v })
+}
+
+// MinBy returns the element with the smallest value of fn, the first one wins on ties
+func MinBy[T any, K constraints.Ordered](collection []T, fn func(T) K) (res T, ok bool) {
+	return extremeBy(collection, fn, func(a, b K) bool { return a < b })
+}
+
+// MaxBy returns the element with the largest value of fn, the first one wins on ties
+func MaxBy[T any, K constraints.Ordered](collection []T, fn func(T) K) (res T, ok bool) {
+	return extremeBy(collection, fn, func(a, b K) bool { return a > b })
+}
+
+func extremeBy[T any, K constraints.Ordered](collection []T, fn func(T) K, better func(a, b K) bool) (res T, ok bool) {
+	if len(collection) == 0 {
+		return
+	}
+
+	res = collection[0]
+	best := fn(res)
+	for _, item := range collection[1:] {
+		if k := fn(item); better(k, best) {
+			res, best = item, k
+		}
+	}
+
+	return res, true
+}
+
+type number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Sum returns the sum of all elements
+func Sum[T number](collection []T) T {
+	var res T
+	for _, v := range collection {
+		res += v
+	}
+
+	return res
+}
+
+// SumBy returns the sum of the values extracted by fn
+func SumBy[T any, N number](collection []T, fn func(T) N) N {
+	var res N
+	for _, item := range collection {
+		res += fn(item)
+	}
+
+	return res
+}
+
+// SumChecked returns the sum of all elements, or an error if it overflows T
+func SumChecked[T constraints.Integer](collection []T) (T, error) {
+	var res, zero T
+	for _, v := range collection {
+		s := res + v
+		if (v > zero && s < res) || (v < zero && s > res) {
+			return res, errors.New("integer overflow")
+		}
+		res = s
+	}
+
+	return res, nil
+}
@@ -4,7 +4,13 @@
 package zarray_test
 
 import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/sohaha/zlsgo"
 	"github.com/sohaha/zlsgo/zarray"
@@ -19,14 +25,82 @@ func TestShuffle(t *testing.T) {
 	t.Log(zarray.Shuffle(l2))
 }
 
+func TestShuffleWithRand(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	l := zarray.Generate(10, func(i int) int { return i })
+
+	res := zarray.ShuffleWithRand(l, rand.New(rand.NewSource(42)))
+	tt.Equal([]int{2, 5, 7, 9, 6, 8, 1, 4, 0, 3}, res)
+	tt.Equal(res, zarray.ShuffleWithRand(l, rand.New(rand.NewSource(42))))
+	tt.EqualTrue(fmt.Sprint(res) != fmt.Sprint(zarray.ShuffleWithRand(l, rand.New(rand.NewSource(7)))))
+	tt.Equal(zarray.Generate(10, func(i int) int { return i }), l)
+	tt.Equal([]int{}, zarray.ShuffleWithRand([]int{}, rand.New(rand.NewSource(42))))
+}
+
 func TestRand(t *testing.T) {
 	t.Log(zarray.Rand(l))
 }
 
+func TestFirstLast(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	v, ok := zarray.First(l)
+	tt.EqualTrue(ok)
+	tt.Equal(0, v)
+
+	v, ok = zarray.Last(l)
+	tt.EqualTrue(ok)
+	tt.Equal(5, v)
+
+	v, ok = zarray.First([]int{})
+	tt.EqualTrue(!ok)
+	tt.Equal(0, v)
+
+	s, ok := zarray.Last([]string(nil))
+	tt.EqualTrue(!ok)
+	tt.Equal("", s)
+}
+
 func TestReverse(t *testing.T) {
 	t.Log(zarray.Reverse(l))
 }
 
+func TestSortStableBy(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	type user struct {
+		name string
+		age  int
+	}
+	users := []user{{"a", 3}, {"b", 1}, {"c", 3}, {"d", 2}, {"e", 1}, {"f", 3}}
+	res := zarray.SortStableBy(users, func(a, b user) bool {
+		return a.age < b.age
+	})
+	tt.Equal([]user{{"b", 1}, {"e", 1}, {"d", 2}, {"a", 3}, {"c", 3}, {"f", 3}}, res)
+	tt.Equal("a", users[0].name)
+}
+
+func TestSortBy(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	type user struct {
+		name string
+		age  int
+	}
+	users := []user{{"c", 30}, {"a", 20}, {"b", 30}, {"d", 10}}
+
+	res := zarray.SortBy(users, func(a, b user) bool { return a.age < b.age })
+	tt.Equal([]user{{"d", 10}, {"a", 20}, {"c", 30}, {"b", 30}}, res)
+	tt.Equal(user{"c", 30}, users[0])
+
+	res = zarray.SortByField(users, func(u user) int { return u.age })
+	tt.Equal([]user{{"d", 10}, {"a", 20}, {"c", 30}, {"b", 30}}, res)
+
+	res = zarray.SortByField(users, func(u user) string { return u.name })
+	tt.Equal([]user{{"a", 20}, {"b", 30}, {"c", 30}, {"d", 10}}, res)
+
+	tt.Equal([]float64{0.5, 1.5, 2}, zarray.SortByField([]float64{2, 0.5, 1.5}, func(f float64) float64 { return f }))
+}
+
 func TestFilter(t *testing.T) {
 	tt := zlsgo.NewTest(t)
 	nl := zarray.Filter(l, func(index int, item int) bool {
@@ -45,6 +119,170 @@ func TestMap(t *testing.T) {
 	tt.Equal([]string{"1//", "2//", "3//"}, nl)
 }
 
+func TestFlatten(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tt.Equal([]int{1, 2, 3, 4}, zarray.Flatten([][]int{{1, 2}, nil, {3}, {}, {4}}))
+	tt.Equal([]int{}, zarray.Flatten([][]int{}))
+	tt.Equal([]int{}, zarray.Flatten([][]int(nil)))
+}
+
+func TestFlatMap(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	res := zarray.FlatMap([]int{1, 2, 3}, func(_ int, v int) []string {
+		if v == 2 {
+			return nil
+		}
+		return []string{ztype.ToString(v), ztype.ToString(v * 10)}
+	})
+	tt.Equal([]string{"1", "10", "3", "30"}, res)
+	tt.Equal([]string{}, zarray.FlatMap([]int{}, func(_ int, v int) []string { return nil }))
+}
+
+func TestReduce(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	type item struct {
+		name  string
+		price int
+	}
+	l := []item{{"a", 1}, {"b", 2}, {"c", 3}}
+
+	tt.Equal(6, zarray.Reduce(l, 0, func(acc int, _ int, v item) int {
+		return acc + v.price
+	}))
+	tt.Equal("a0b1c2", zarray.Reduce(l, "", func(acc string, i int, v item) string {
+		return acc + v.name + strconv.Itoa(i)
+	}))
+	tt.Equal(10, zarray.Reduce([]item{}, 10, func(acc int, _ int, v item) int {
+		return acc + v.price
+	}))
+}
+
+func TestMapConcurrent(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	l := zarray.Generate(50, func(i int) int { return i })
+	expected := zarray.Map(l, func(_ int, v int) string { return strconv.Itoa(v * 2) })
+	double := func(_ int, v int) string {
+		time.Sleep(time.Millisecond)
+		return strconv.Itoa(v * 2)
+	}
+
+	tt.Equal(expected, zarray.MapConcurrent(l, 8, double))
+	tt.Equal(expected, zarray.MapConcurrent(l, 100, double))
+	tt.Equal([]string{}, zarray.MapConcurrent([]int{}, 4, double))
+
+	var running, peak int32
+	tt.Equal(expected, zarray.MapConcurrent(l, 1, func(i int, v int) string {
+		if n := atomic.AddInt32(&running, 1); n > atomic.LoadInt32(&peak) {
+			atomic.StoreInt32(&peak, n)
+		}
+		defer atomic.AddInt32(&running, -1)
+		return double(i, v)
+	}))
+	tt.Equal(int32(1), peak)
+
+	tt.Run("panic", func(tt *zlsgo.TestUtil) {
+		defer func() {
+			tt.Equal("boom", recover())
+		}()
+		zarray.MapConcurrent(l, 4, func(i int, v int) string {
+			if i == 10 {
+				panic("boom")
+			}
+			return ""
+		})
+	})
+}
+
+func TestParallelMap(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	var running, peak int32
+	res := zarray.ParallelMap(l2, func(i int, v int) string {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return strconv.Itoa(v)
+	}, 3)
+	tt.Equal(zarray.Map(l2, func(_ int, v int) string { return strconv.Itoa(v) }), res)
+	tt.EqualTrue(atomic.LoadInt32(&peak) <= 3)
+
+	tt.Equal([]int{0, 2, 4}, zarray.ParallelMap([]int{0, 1, 2}, func(_ int, v int) int { return v * 2 }, 0))
+	tt.Equal([]int{}, zarray.ParallelMap([]int{}, func(_ int, v int) int { return v }, 2))
+
+	defer func() {
+		tt.Equal("boom", recover())
+	}()
+	zarray.ParallelMap(l, func(i int, v int) int {
+		if v == 3 {
+			panic("boom")
+		}
+		return v
+	}, 2)
+}
+
+func TestMapError(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	l := []string{"1", "2", "3"}
+	failAt := func(n int) func(int, string) (int, error) {
+		return func(i int, v string) (int, error) {
+			if i == n {
+				return 0, errors.New("fail")
+			}
+			return strconv.Atoi(v)
+		}
+	}
+
+	res, err := zarray.MapError(l, failAt(-1))
+	tt.NoError(err)
+	tt.Equal([]int{1, 2, 3}, res)
+
+	res, err = zarray.MapError(l, failAt(0))
+	tt.EqualTrue(err != nil)
+	tt.Equal([]int{}, res)
+
+	res, err = zarray.MapError(l, failAt(2))
+	tt.EqualTrue(err != nil)
+	tt.Equal([]int{1, 2}, res)
+
+	res, err = zarray.MapError([]string{}, failAt(0))
+	tt.NoError(err)
+	tt.EqualTrue(res == nil)
+}
+
+func TestMapFilterError(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	l := []int{1, 2, 3, 4}
+
+	res, err := zarray.MapFilterError(l, func(i int, v int) (string, bool, error) {
+		return strconv.Itoa(v), true, nil
+	})
+	tt.NoError(err)
+	tt.Equal([]string{"1", "2", "3", "4"}, res)
+
+	res, err = zarray.MapFilterError(l, func(i int, v int) (string, bool, error) {
+		return strconv.Itoa(v), v%2 == 0, nil
+	})
+	tt.NoError(err)
+	tt.Equal([]string{"2", "4"}, res)
+
+	res, err = zarray.MapFilterError(l, func(i int, v int) (string, bool, error) {
+		if i == 2 {
+			return "", false, errors.New("fatal")
+		}
+		return strconv.Itoa(v), true, nil
+	})
+	tt.EqualTrue(err != nil)
+	tt.Equal([]string{"1", "2"}, res)
+}
+
 func TestDiff(t *testing.T) {
 	tt := zlsgo.NewTest(t)
 
@@ -54,6 +292,15 @@ func TestDiff(t *testing.T) {
 	tt.Equal([]int{}, n2)
 }
 
+func TestIntersect(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tt.Equal([]int{2, 3}, zarray.Intersect([]int{1, 2, 2, 3, 4}, []int{3, 2, 2, 5}))
+	tt.Equal([]int{}, zarray.Intersect([]int{1, 2}, []int{3}))
+	tt.Equal([]int{}, zarray.Intersect(nil, []int{3}))
+	tt.Equal([]string{"b"}, zarray.Intersect([]string{"a", "b"}, []string{"b", "c"}))
+}
+
 func TestPop(t *testing.T) {
 	tt := zlsgo.NewTest(t)
 
@@ -76,6 +323,29 @@ func TestShift(t *testing.T) {
 	tt.Equal([]int{2, 3, 4, 5}, l1)
 }
 
+func TestForEach(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	var visited []int
+	zarray.ForEach(l, func(i int, v int) bool {
+		visited = append(visited, v)
+		return i < 2
+	})
+	tt.Equal([]int{0, 1, 2}, visited)
+
+	visited = visited[:0]
+	zarray.ForEach(l, func(_ int, v int) bool {
+		visited = append(visited, v)
+		return true
+	})
+	tt.Equal(l, visited)
+
+	zarray.ForEach([]int(nil), func(_ int, _ int) bool {
+		tt.Fatal("should not be called")
+		return true
+	})
+}
+
 func TestContains(t *testing.T) {
 	tt := zlsgo.NewTest(t)
 	tt.EqualTrue(!zarray.Contains(l, 54))
@@ -85,6 +355,17 @@ func TestContains(t *testing.T) {
 	tt.EqualTrue(zarray.Contains(l2, 54))
 }
 
+func TestCount(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tt.Equal(3, zarray.Count(l2, 5))
+	tt.Equal(0, zarray.Count(l2, 100))
+	tt.Equal(0, zarray.Count([]int(nil), 1))
+
+	tt.Equal(3, zarray.CountBy(l, func(_ int, v int) bool { return v%2 == 0 }))
+	tt.Equal(0, zarray.CountBy([]int{}, func(_ int, v int) bool { return true }))
+}
+
 func TestUnique(t *testing.T) {
 	tt := zlsgo.NewTest(t)
 	a := append(l, l2...)
@@ -94,6 +375,20 @@ func TestUnique(t *testing.T) {
 	t.Log(unia)
 }
 
+func TestUniqueBy(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	type item struct {
+		tags []string
+		id   int
+	}
+	items := []item{{[]string{"a"}, 1}, {[]string{"b"}, 2}, {[]string{"c"}, 1}}
+	res := zarray.UniqueBy(items, func(i item) int { return i.id })
+	tt.Equal([]item{{[]string{"a"}, 1}, {[]string{"b"}, 2}}, res)
+
+	tt.Equal([]item{}, zarray.UniqueBy([]item{}, func(i item) int { return i.id }))
+}
+
 func TestFind(t *testing.T) {
 	tt := zlsgo.NewTest(t)
 	a := []map[string]string{
@@ -114,3 +409,302 @@ func TestFind(t *testing.T) {
 	tt.EqualTrue(!ok)
 	tt.Equal("", v["name"])
 }
+
+func TestGenerate(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tt.Equal([]int{1, 3, 5, 7}, zarray.Generate(4, func(i int) int {
+		return 2*i + 1
+	}))
+
+	a, b := 0, 1
+	tt.Equal([]int{0, 1, 1, 2, 3, 5, 8}, zarray.Generate(7, func(int) int {
+		v := a
+		a, b = b, a+b
+		return v
+	}))
+
+	tt.Equal([]string{}, zarray.Generate(0, func(int) string { return "x" }))
+	tt.Equal([]string{}, zarray.Generate(-1, func(int) string { return "x" }))
+}
+
+func TestEvery(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	l := []int{2, 4, 5, 6, 8}
+
+	tt.EqualTrue(zarray.Every(l[:2], func(_ int, v int) bool { return v%2 == 0 }))
+	tt.EqualTrue(zarray.Every([]int{}, func(_ int, v int) bool { return false }))
+	tt.EqualTrue(!zarray.Every(l, func(i int, v int) bool {
+		if i > 2 {
+			panic("predicate called after a false result")
+		}
+		return v%2 == 0
+	}))
+}
+
+func BenchmarkEvery(b *testing.B) {
+	l := zarray.Generate(1000, func(i int) int { return i * 2 })
+	b.Run("Every", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = zarray.Every(l, func(_ int, v int) bool { return v%2 == 0 })
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ok := true
+			for _, v := range l {
+				if v%2 != 0 {
+					ok = false
+					break
+				}
+			}
+			_ = ok
+		}
+	})
+}
+
+func TestZipN(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tt.Equal([][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}, zarray.ZipN([]int{1, 2, 3}, []int{4, 5, 6}, []int{7, 8, 9}))
+	tt.Equal([][]int{{1, 4, 7}}, zarray.ZipN([]int{1, 2, 3}, []int{4, 5}, []int{7}))
+	tt.Equal([][]string{{"a"}, {"b"}}, zarray.ZipN([]string{"a", "b"}))
+	tt.Equal([][]int{}, zarray.ZipN([]int{1, 2}, []int{}))
+	tt.Equal([][]int{}, zarray.ZipN[int]())
+}
+
+func TestPartitionN(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	l := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tt.Equal([][]int{{1, 2}, {3, 4}, {5, 6}}, zarray.PartitionN(l[:6], 3))
+	tt.Equal([][]int{{1, 2, 3}, {4, 5}, {6, 7}}, zarray.PartitionN(l, 3))
+	tt.Equal([][]int{l}, zarray.PartitionN(l, 1))
+	tt.Equal([][]int{{1}, {2}, {3}}, zarray.PartitionN(l[:3], 5))
+	tt.Equal([][]int{}, zarray.PartitionN([]int{}, 2))
+	tt.EqualTrue(zarray.PartitionN(l, 0) == nil)
+	tt.EqualTrue(zarray.PartitionN(l, -1) == nil)
+}
+
+func TestEveryNth(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	l := []int{0, 1, 2, 3, 4, 5}
+
+	res, err := zarray.EveryNth(l, 1)
+	tt.NoError(err)
+	tt.Equal(l, res)
+
+	res, err = zarray.EveryNth(l, 2)
+	tt.NoError(err)
+	tt.Equal([]int{0, 2, 4}, res)
+
+	res, err = zarray.EveryNth(l, 4)
+	tt.NoError(err)
+	tt.Equal([]int{0, 4}, res)
+
+	res, err = zarray.EveryNth(l, 6)
+	tt.NoError(err)
+	tt.Equal([]int{0}, res)
+
+	res, err = zarray.EveryNth(l, 10)
+	tt.NoError(err)
+	tt.Equal([]int{0}, res)
+
+	_, err = zarray.EveryNth(l, 0)
+	tt.EqualTrue(err != nil)
+	_, err = zarray.EveryNth(l, -1)
+	tt.EqualTrue(err != nil)
+}
+
+func TestChunk(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	res, err := zarray.Chunk([]int{0, 1, 2, 3, 4}, 2)
+	tt.NoError(err)
+	tt.Equal([][]int{{0, 1}, {2, 3}, {4}}, res)
+
+	res, err = zarray.Chunk([]int{0, 1, 2, 3}, 2)
+	tt.NoError(err)
+	tt.Equal([][]int{{0, 1}, {2, 3}}, res)
+
+	res, err = zarray.Chunk([]int{}, 2)
+	tt.NoError(err)
+	tt.Equal([][]int{}, res)
+
+	_, err = zarray.Chunk([]int{1}, 0)
+	tt.EqualTrue(err != nil)
+}
+
+func TestChunkOverlap(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	res, err := zarray.ChunkOverlap(l, 2, 0)
+	tt.NoError(err)
+	tt.Equal([][]int{{0, 1}, {2, 3}, {4, 5}}, res)
+
+	res, err = zarray.ChunkOverlap(l, 4, 0)
+	tt.NoError(err)
+	tt.Equal([][]int{{0, 1, 2, 3}, {4, 5}}, res)
+
+	res, err = zarray.ChunkOverlap(l, 3, 1)
+	tt.NoError(err)
+	tt.Equal([][]int{{0, 1, 2}, {2, 3, 4}, {4, 5}}, res)
+
+	res, err = zarray.ChunkOverlap(l, 3, 2)
+	tt.NoError(err)
+	tt.Equal([][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, res)
+
+	res, err = zarray.ChunkOverlap(l, 10, 2)
+	tt.NoError(err)
+	tt.Equal([][]int{{0, 1, 2, 3, 4, 5}}, res)
+
+	res, err = zarray.ChunkOverlap([]int{}, 3, 1)
+	tt.NoError(err)
+	tt.Equal([][]int{}, res)
+
+	_, err = zarray.ChunkOverlap(l, 3, 3)
+	tt.EqualTrue(err != nil)
+	_, err = zarray.ChunkOverlap(l, 3, -1)
+	tt.EqualTrue(err != nil)
+	_, err = zarray.ChunkOverlap(l, 0, 0)
+	tt.EqualTrue(err != nil)
+}
+
+func TestDiff3(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	base := []string{"a", "b", "c", "d"}
+
+	merged, conflicts := zarray.Diff3(base, []string{"a", "B", "c", "d"}, []string{"a", "b", "c", "D", "e"})
+	tt.Equal([]string{"a", "B", "c", "D", "e"}, merged)
+	tt.Equal([]string{}, conflicts)
+
+	merged, conflicts = zarray.Diff3(base, []string{"a", "x", "c", "d"}, []string{"a", "y", "C", "d"})
+	tt.Equal([]string{"a", "b", "C", "d"}, merged)
+	tt.Equal([]string{"x", "y"}, conflicts)
+
+	merged, conflicts = zarray.Diff3(base, base, []string{"a", "b"})
+	tt.Equal([]string{"a", "b"}, merged)
+	tt.Equal([]string{}, conflicts)
+
+	merged, conflicts = zarray.Diff3(base, []string{"a", "z", "c", "d"}, base)
+	tt.Equal([]string{"a", "z", "c", "d"}, merged)
+	tt.Equal([]string{}, conflicts)
+
+	merged, conflicts = zarray.Diff3(base, []string{"a", "b", "C"}, []string{"a", "b", "C"})
+	tt.Equal([]string{"a", "b", "C"}, merged)
+	tt.Equal([]string{}, conflicts)
+
+	merged, conflicts = zarray.Diff3(base, base, base)
+	tt.Equal(base, merged)
+	tt.Equal([]string{}, conflicts)
+
+	merged, conflicts = zarray.Diff3([]string{}, []string{"x"}, []string{"y"})
+	tt.Equal([]string{}, merged)
+	tt.Equal([]string{"x", "y"}, conflicts)
+}
+
+func TestGroupBy(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	res := zarray.GroupBy([]int{1, 2, 3, 4, 5, 6}, func(_ int, v int) string {
+		if v%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	tt.Equal(map[string][]int{"odd": {1, 3, 5}, "even": {2, 4, 6}}, res)
+
+	idx := zarray.GroupBy([]string{"a", "b", "c"}, func(i int, _ string) bool {
+		return i > 0
+	})
+	tt.Equal([]string{"a"}, idx[false])
+	tt.Equal([]string{"b", "c"}, idx[true])
+
+	tt.Equal(0, len(zarray.GroupBy([]int(nil), func(_ int, v int) int { return v })))
+}
+
+func TestZip(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	pairs := zarray.Zip([]int{1, 2, 3}, []string{"a", "b"})
+	tt.Equal([]zarray.Pair[int, string]{{First: 1, Second: "a"}, {First: 2, Second: "b"}}, pairs)
+
+	a, b := zarray.Unzip(pairs)
+	tt.Equal([]int{1, 2}, a)
+	tt.Equal([]string{"a", "b"}, b)
+
+	tt.Equal(0, len(zarray.Zip([]int{}, []string{"a"})))
+	a, b = zarray.Unzip([]zarray.Pair[int, string]{})
+	tt.Equal([]int{}, a)
+	tt.Equal([]string{}, b)
+}
+
+func TestMinMax(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	v, ok := zarray.Min(l2)
+	tt.EqualTrue(ok)
+	tt.Equal(0, v)
+
+	v, ok = zarray.Max(l2)
+	tt.EqualTrue(ok)
+	tt.Equal(43543, v)
+
+	s, ok := zarray.Min([]string{"b", "a", "c"})
+	tt.EqualTrue(ok)
+	tt.Equal("a", s)
+
+	_, ok = zarray.Max([]float64{})
+	tt.EqualTrue(!ok)
+
+	type user struct {
+		name string
+		age  int
+	}
+	users := []user{{"a", 30}, {"b", 20}, {"c", 40}, {"d", 20}}
+
+	u, ok := zarray.MinBy(users, func(u user) int { return u.age })
+	tt.EqualTrue(ok)
+	tt.Equal("b", u.name)
+
+	u, ok = zarray.MaxBy(users, func(u user) int { return u.age })
+	tt.EqualTrue(ok)
+	tt.Equal("c", u.name)
+
+	_, ok = zarray.MinBy([]user(nil), func(u user) int { return u.age })
+	tt.EqualTrue(!ok)
+}
+
+func TestSum(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tt.Equal(15, zarray.Sum(l))
+	tt.Equal(0, zarray.Sum([]int(nil)))
+	tt.Equal(4.0, zarray.Sum([]float64{1.5, 2.5}))
+
+	type item struct {
+		price float64
+		qty   int
+	}
+	items := []item{{1.5, 2}, {2, 3}}
+	tt.Equal(5, zarray.SumBy(items, func(i item) int { return i.qty }))
+	tt.Equal(9.0, zarray.SumBy(items, func(i item) float64 { return i.price * float64(i.qty) }))
+
+	n, err := zarray.SumChecked([]int8{100, 27})
+	tt.NoError(err)
+	tt.Equal(int8(127), n)
+
+	_, err = zarray.SumChecked([]int8{100, 28})
+	tt.EqualTrue(err != nil)
+
+	_, err = zarray.SumChecked([]int8{-100, -29})
+	tt.EqualTrue(err != nil)
+
+	_, err = zarray.SumChecked([]uint8{200, 56})
+	tt.EqualTrue(err != nil)
+
+	u, err := zarray.SumChecked([]uint8{200, 55})
+	tt.NoError(err)
+	tt.Equal(uint8(255), u)
+}
@@ -0,0 +1,130 @@
+//go:build go1.18
+// +build go1.18
+
+package zarray
+
+import (
+	"sort"
+)
+
+type (
+	// Trie prefix tree keyed by string, not safe for concurrent use
+	Trie[V any] struct {
+		root *trieNode[V]
+		size int
+	}
+	trieNode[V any] struct {
+		children map[rune]*trieNode[V]
+		value    V
+		end      bool
+	}
+)
+
+// NewTrie create a prefix tree
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{root: newTrieNode[V]()}
+}
+
+func newTrieNode[V any]() *trieNode[V] {
+	return &trieNode[V]{children: make(map[rune]*trieNode[V])}
+}
+
+// Insert the key with value, existing value is replaced
+func (t *Trie[V]) Insert(key string, value V) {
+	n := t.root
+	for _, r := range key {
+		child, ok := n.children[r]
+		if !ok {
+			child = newTrieNode[V]()
+			n.children[r] = child
+		}
+		n = child
+	}
+
+	if !n.end {
+		t.size++
+	}
+	n.value, n.end = value, true
+}
+
+// Search the value of key
+func (t *Trie[V]) Search(key string) (value V, ok bool) {
+	n := t.find(key)
+	if n == nil || !n.end {
+		return
+	}
+	return n.value, true
+}
+
+// StartsWith returns all keys with the prefix in lexical order
+func (t *Trie[V]) StartsWith(prefix string) []string {
+	keys := make([]string, 0)
+	n := t.find(prefix)
+	if n == nil {
+		return keys
+	}
+
+	var walk func(n *trieNode[V], path []rune)
+	walk = func(n *trieNode[V], path []rune) {
+		if n.end {
+			keys = append(keys, string(path))
+		}
+		for r, child := range n.children {
+			walk(child, append(path, r))
+		}
+	}
+	walk(n, []rune(prefix))
+
+	sort.Strings(keys)
+	return keys
+}
+
+// Delete the key, returns false if the key does not exist
+func (t *Trie[V]) Delete(key string) bool {
+	runes := []rune(key)
+	nodes := make([]*trieNode[V], 0, len(runes)+1)
+	n := t.root
+	nodes = append(nodes, n)
+	for _, r := range runes {
+		child, ok := n.children[r]
+		if !ok {
+			return false
+		}
+		n = child
+		nodes = append(nodes, n)
+	}
+
+	if !n.end {
+		return false
+	}
+
+	var zero V
+	n.value, n.end = zero, false
+	t.size--
+
+	for i := len(runes) - 1; i >= 0; i-- {
+		child := nodes[i+1]
+		if child.end || len(child.children) > 0 {
+			break
+		}
+		delete(nodes[i].children, runes[i])
+	}
+	return true
+}
+
+// Len returns the number of keys
+func (t *Trie[V]) Len() int {
+	return t.size
+}
+
+func (t *Trie[V]) find(key string) *trieNode[V] {
+	n := t.root
+	for _, r := range key {
+		child, ok := n.children[r]
+		if !ok {
+			return nil
+		}
+		n = child
+	}
+	return n
+}
@@ -0,0 +1,73 @@
+//go:build go1.18
+// +build go1.18
+
+package zarray_test
+
+import (
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+	"github.com/sohaha/zlsgo/zarray"
+)
+
+func TestTrie(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	trie := zarray.NewTrie[int]()
+	tt.Equal(0, trie.Len())
+	_, ok := trie.Search("")
+	tt.EqualTrue(!ok)
+	tt.Equal([]string{}, trie.StartsWith("a"))
+	tt.EqualTrue(!trie.Delete("a"))
+
+	trie.Insert("apple", 1)
+	trie.Insert("app", 2)
+	trie.Insert("application", 3)
+	trie.Insert("banana", 4)
+	trie.Insert("app", 5)
+	tt.Equal(4, trie.Len())
+
+	v, ok := trie.Search("app")
+	tt.EqualTrue(ok)
+	tt.Equal(5, v)
+	_, ok = trie.Search("ap")
+	tt.EqualTrue(!ok)
+	_, ok = trie.Search("apples")
+	tt.EqualTrue(!ok)
+
+	tt.Equal([]string{"app", "apple", "application"}, trie.StartsWith("app"))
+	tt.Equal([]string{"app", "apple", "application", "banana"}, trie.StartsWith(""))
+	tt.Equal([]string{}, trie.StartsWith("c"))
+
+	tt.EqualTrue(trie.Delete("app"))
+	tt.EqualTrue(!trie.Delete("app"))
+	tt.EqualTrue(!trie.Delete("ap"))
+	_, ok = trie.Search("app")
+	tt.EqualTrue(!ok)
+	v, ok = trie.Search("apple")
+	tt.EqualTrue(ok)
+	tt.Equal(1, v)
+	tt.Equal([]string{"apple", "application"}, trie.StartsWith("ap"))
+
+	tt.EqualTrue(trie.Delete("application"))
+	tt.EqualTrue(trie.Delete("apple"))
+	tt.Equal([]string{}, trie.StartsWith("a"))
+	tt.Equal(1, trie.Len())
+}
+
+func TestTrieUnicode(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	trie := zarray.NewTrie[string]()
+	trie.Insert("你好", "hello")
+	trie.Insert("你好世界", "hello world")
+	trie.Insert("日本", "japan")
+
+	v, ok := trie.Search("你好")
+	tt.EqualTrue(ok)
+	tt.Equal("hello", v)
+	tt.Equal([]string{"你好", "你好世界"}, trie.StartsWith("你"))
+
+	tt.EqualTrue(trie.Delete("你好"))
+	tt.Equal([]string{"你好世界"}, trie.StartsWith("你"))
+}
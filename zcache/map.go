@@ -0,0 +1,68 @@
+//go:build go1.18
+// +build go1.18
+
+package zcache
+
+import (
+	"sync"
+)
+
+// Map concurrent safe generic map backed by sync.Map
+type Map[K comparable, V any] struct {
+	m sync.Map
+}
+
+// NewMap create a concurrent safe generic map
+func NewMap[K comparable, V any]() *Map[K, V] {
+	return &Map[K, V]{}
+}
+
+// Set store value for key
+func (m *Map[K, V]) Set(key K, value V) {
+	m.m.Store(key, value)
+}
+
+// Get load value for key
+func (m *Map[K, V]) Get(key K) (value V, ok bool) {
+	v, ok := m.m.Load(key)
+	if !ok {
+		return
+	}
+	value, _ = v.(V)
+	return value, true
+}
+
+// Delete remove key
+func (m *Map[K, V]) Delete(key K) {
+	m.m.Delete(key)
+}
+
+// Range call fn for each key and value, iteration stops when fn returns false
+func (m *Map[K, V]) Range(fn func(key K, value V) bool) {
+	m.m.Range(func(k, v interface{}) bool {
+		key, _ := k.(K)
+		value, _ := v.(V)
+		return fn(key, value)
+	})
+}
+
+// Len number of keys, counted by iterating the map
+func (m *Map[K, V]) Len() int {
+	n := 0
+	m.m.Range(func(_, _ interface{}) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// Keys all keys in unspecified order
+func (m *Map[K, V]) Keys() []K {
+	keys := make([]K, 0)
+	m.m.Range(func(k, _ interface{}) bool {
+		key, _ := k.(K)
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
@@ -0,0 +1,86 @@
+//go:build go1.18
+// +build go1.18
+
+package zcache
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestMap(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	m := NewMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	v, ok := m.Get("b")
+	tt.EqualTrue(ok)
+	tt.Equal(2, v)
+	tt.Equal(3, m.Len())
+
+	keys := m.Keys()
+	sort.Strings(keys)
+	tt.Equal([]string{"a", "b", "c"}, keys)
+
+	m.Delete("b")
+	v, ok = m.Get("b")
+	tt.EqualTrue(!ok)
+	tt.Equal(0, v)
+	tt.Equal(2, m.Len())
+
+	n := 0
+	m.Range(func(key string, value int) bool {
+		n++
+		return false
+	})
+	tt.Equal(1, n)
+}
+
+func TestMapNilInterface(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	m := NewMap[interface{}, error]()
+	m.Set("a", nil)
+	m.Set(nil, errors.New("nil key"))
+
+	v, ok := m.Get("a")
+	tt.EqualTrue(ok)
+	tt.EqualTrue(v == nil)
+
+	n := 0
+	m.Range(func(key interface{}, value error) bool {
+		n++
+		return true
+	})
+	tt.Equal(2, n)
+	tt.Equal(2, len(m.Keys()))
+}
+
+func TestMapConcurrent(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	m := NewMap[int, int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			m.Set(i, i*2)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			_, _ = m.Get(i)
+			_ = m.Len()
+		}(i)
+	}
+	wg.Wait()
+
+	tt.Equal(100, m.Len())
+	v, _ := m.Get(50)
+	tt.Equal(100, v)
+}
@@ -0,0 +1,236 @@
+//go:build go1.18
+// +build go1.18
+
+package zcache
+
+import (
+	"container/list"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// ShardedCache concurrent LRU cache that distributes keys across independent shards
+type ShardedCache[K comparable, V any] struct {
+	hash   func(key K) uint32
+	shards []*shard[K, V]
+}
+
+type (
+	shard[K comparable, V any] struct {
+		items    map[K]*list.Element
+		list     *list.List
+		mu       sync.Mutex
+		capacity int
+	}
+
+	shardEntry[K comparable, V any] struct {
+		key   K
+		value V
+	}
+)
+
+// NewSharded create a sharded LRU cache, each shard holds up to capacityPerShard items.
+// Every comparable key is hashed built in, pointers and channels by address and structs
+// and arrays field by field through reflect, pass hash to speed up struct keys
+func NewSharded[K comparable, V any](shards int, capacityPerShard int, hash ...func(key K) uint32) *ShardedCache[K, V] {
+	if shards < 1 {
+		shards = 1
+	}
+	if capacityPerShard < 1 {
+		capacityPerShard = 1
+	}
+
+	c := &ShardedCache[K, V]{shards: make([]*shard[K, V], shards)}
+	if len(hash) > 0 && hash[0] != nil {
+		c.hash = hash[0]
+	} else {
+		c.hash = func(key K) uint32 {
+			return keyHasher(key)
+		}
+	}
+	for i := range c.shards {
+		c.shards[i] = &shard[K, V]{
+			items:    make(map[K]*list.Element, capacityPerShard),
+			list:     list.New(),
+			capacity: capacityPerShard,
+		}
+	}
+	return c
+}
+
+func (c *ShardedCache[K, V]) shard(key K) *shard[K, V] {
+	return c.shards[c.hash(key)%uint32(len(c.shards))]
+}
+
+// Set an item into cache
+func (c *ShardedCache[K, V]) Set(key K, value V) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.items[key]; ok {
+		e.Value.(*shardEntry[K, V]).value = value
+		s.list.MoveToFront(e)
+		return
+	}
+
+	if s.list.Len() >= s.capacity {
+		if e := s.list.Back(); e != nil {
+			s.list.Remove(e)
+			delete(s.items, e.Value.(*shardEntry[K, V]).key)
+		}
+	}
+
+	s.items[key] = s.list.PushFront(&shardEntry[K, V]{key: key, value: value})
+}
+
+// Get value of key from cache with result
+func (c *ShardedCache[K, V]) Get(key K) (value V, ok bool) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.items[key]
+	if !ok {
+		return
+	}
+
+	s.list.MoveToFront(e)
+	return e.Value.(*shardEntry[K, V]).value, true
+}
+
+// Delete item by key from cache
+func (c *ShardedCache[K, V]) Delete(key K) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.items[key]; ok {
+		s.list.Remove(e)
+		delete(s.items, key)
+	}
+}
+
+// Len returns the number of items in all shards
+func (c *ShardedCache[K, V]) Len() (n int) {
+	for _, s := range c.shards {
+		s.mu.Lock()
+		n += s.list.Len()
+		s.mu.Unlock()
+	}
+	return
+}
+
+// ForEach walk through all items in cache
+func (c *ShardedCache[K, V]) ForEach(walker func(key K, value V) bool) {
+	for _, s := range c.shards {
+		s.mu.Lock()
+		for e := s.list.Front(); e != nil; e = e.Next() {
+			entry := e.Value.(*shardEntry[K, V])
+			if !walker(entry.key, entry.value) {
+				s.mu.Unlock()
+				return
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func keyHasher(key interface{}) uint32 {
+	switch k := key.(type) {
+	case string:
+		return uint32(hasher(k))
+	case int:
+		return mix32(uint64(k))
+	case int8:
+		return mix32(uint64(k))
+	case int16:
+		return mix32(uint64(k))
+	case int32:
+		return mix32(uint64(k))
+	case int64:
+		return mix32(uint64(k))
+	case uint:
+		return mix32(uint64(k))
+	case uint8:
+		return mix32(uint64(k))
+	case uint16:
+		return mix32(uint64(k))
+	case uint32:
+		return mix32(uint64(k))
+	case uint64:
+		return mix32(k)
+	case uintptr:
+		return mix32(uint64(k))
+	case float32:
+		return floatHasher(float64(k))
+	case float64:
+		return floatHasher(k)
+	case bool:
+		if k {
+			return 1
+		}
+		return 0
+	case nil:
+		return 0
+	}
+
+	return valueHasher(reflect.ValueOf(key))
+}
+
+// valueHasher hash any comparable value, pointers and channels by address,
+// structs and arrays by combining their fields or elements
+func valueHasher(v reflect.Value) uint32 {
+	switch v.Kind() {
+	case reflect.String:
+		return uint32(hasher(v.String()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix32(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mix32(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return floatHasher(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		return floatHasher(real(c))*31 + floatHasher(imag(c))
+	case reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+	case reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+		return mix32(uint64(v.Pointer()))
+	case reflect.Interface:
+		if !v.IsNil() {
+			return valueHasher(v.Elem())
+		}
+	case reflect.Struct:
+		h := uint32(17)
+		for i := 0; i < v.NumField(); i++ {
+			h = h*31 + valueHasher(v.Field(i))
+		}
+		return h
+	case reflect.Array:
+		h := uint32(17)
+		for i := 0; i < v.Len(); i++ {
+			h = h*31 + valueHasher(v.Index(i))
+		}
+		return h
+	}
+	return 0
+}
+
+// floatHasher hash -0 like 0, they are the same map key
+func floatHasher(f float64) uint32 {
+	if f == 0 {
+		f = 0
+	}
+	return mix32(math.Float64bits(f))
+}
+
+func mix32(h uint64) uint32 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	return uint32(h)
+}
@@ -0,0 +1,182 @@
+//go:build go1.18
+// +build go1.18
+
+package zcache
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestShardedCache(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	c := NewSharded[string, int](4, 10)
+	for i := 0; i < 20; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	tt.Equal(20, c.Len())
+
+	used := 0
+	for _, s := range c.shards {
+		if s.list.Len() > 0 {
+			used++
+		}
+	}
+	tt.EqualTrue(used > 1)
+
+	v, ok := c.Get("1")
+	tt.EqualTrue(ok)
+	tt.Equal(1, v)
+
+	c.Delete("1")
+	_, ok = c.Get("1")
+	tt.EqualTrue(!ok)
+	tt.Equal(19, c.Len())
+
+	n := 0
+	c.ForEach(func(key string, value int) bool {
+		n++
+		return n < 5
+	})
+	tt.Equal(5, n)
+}
+
+func TestShardedCacheEviction(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	c := NewSharded[int, int](2, 2)
+	var a, b []int
+	for i := 0; len(a) < 3 || len(b) < 2; i++ {
+		if c.shard(i) == c.shards[0] {
+			if len(a) < 3 {
+				a = append(a, i)
+			}
+		} else if len(b) < 2 {
+			b = append(b, i)
+		}
+	}
+
+	c.Set(b[0], b[0])
+	c.Set(b[1], b[1])
+	for _, k := range a {
+		c.Set(k, k)
+	}
+
+	_, ok := c.Get(a[0])
+	tt.EqualTrue(!ok)
+	_, ok = c.Get(a[2])
+	tt.EqualTrue(ok)
+	_, ok = c.Get(b[0])
+	tt.EqualTrue(ok)
+	_, ok = c.Get(b[1])
+	tt.EqualTrue(ok)
+}
+
+func TestShardedCacheSingle(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	c := NewSharded[string, string](1, 2)
+	c.Set("a", "1")
+	c.Set("b", "2")
+	_, _ = c.Get("a")
+	c.Set("c", "3")
+
+	_, ok := c.Get("b")
+	tt.EqualTrue(!ok)
+	v, ok := c.Get("a")
+	tt.EqualTrue(ok)
+	tt.Equal("1", v)
+	v, ok = c.Get("c")
+	tt.EqualTrue(ok)
+	tt.Equal("3", v)
+
+	c.Set("c", "33")
+	v, _ = c.Get("c")
+	tt.Equal("33", v)
+	tt.Equal(2, c.Len())
+}
+
+func TestShardedCacheConcurrent(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	c := NewSharded[int, int](8, 100)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c.Set(j, i)
+				_, _ = c.Get(j)
+				if j%10 == 0 {
+					c.Delete(j)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	tt.EqualTrue(c.Len() <= 800)
+}
+
+func TestShardedCacheKeys(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	f := NewSharded[float64, int](8, 10)
+	negZero := math.Copysign(0, -1)
+	f.Set(0, 1)
+	f.Set(negZero, 2)
+	tt.Equal(1, f.Len())
+	v, _ := f.Get(0)
+	tt.Equal(2, v)
+
+	type name string
+	n := NewSharded[name, int](8, 10)
+	tt.Equal(keyHasher("zls"), n.hash("zls"))
+
+	type point struct{ x, y int }
+	p := NewSharded[point, int](8, 10, func(key point) uint32 {
+		return uint32(key.x*31 + key.y)
+	})
+	p.Set(point{1, 2}, 3)
+	v, ok := p.Get(point{1, 2})
+	tt.EqualTrue(ok)
+	tt.Equal(3, v)
+
+	ps := NewSharded[point, int](8, 10)
+	ps.Set(point{1, 2}, 1)
+	ps.Set(point{2, 1}, 2)
+	v, ok = ps.Get(point{1, 2})
+	tt.EqualTrue(ok)
+	tt.Equal(1, v)
+	tt.Equal(ps.hash(point{1, 2}), ps.hash(point{1, 2}))
+
+	type floats struct {
+		f [2]float64
+	}
+	fs := NewSharded[floats, int](8, 10)
+	fs.Set(floats{[2]float64{0, 1}}, 1)
+	fs.Set(floats{[2]float64{negZero, 1}}, 2)
+	tt.Equal(1, fs.Len())
+
+	type node struct{ name string }
+	a, b := &node{"a"}, &node{"a"}
+	pt := NewSharded[*node, int](8, 10)
+	pt.Set(a, 1)
+	pt.Set(b, 2)
+	tt.Equal(2, pt.Len())
+	v, _ = pt.Get(a)
+	tt.Equal(1, v)
+	tt.Equal(pt.hash(a), pt.hash(a))
+
+	i := NewSharded[interface{}, int](8, 10)
+	i.Set(point{1, 2}, 1)
+	i.Set("zls", 2)
+	i.Set(nil, 3)
+	tt.Equal(3, i.Len())
+	tt.Equal(keyHasher(point{1, 2}), i.hash(point{1, 2}))
+}
@@ -2,6 +2,7 @@ package zjson
 
 import (
 	"bytes"
+	jsongo "encoding/json"
 	"sort"
 
 	"github.com/sohaha/zlsgo/zstring"
@@ -53,6 +54,24 @@ func FormatOptions(json []byte, opts *StFormatOptions) []byte {
 	return buf
 }
 
+// Compact remove insignificant whitespace, invalid json returns a parse error
+func Compact(json []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(json)))
+	if err := jsongo.Compact(buf, json); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Indent indent json with prefix and indent, invalid json returns a parse error
+func Indent(json []byte, prefix, indent string) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, len(json)*2))
+	if err := jsongo.Indent(buf, json, prefix, indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func Ugly(json []byte) []byte {
 	jsonStr, err := Discard(zstring.Bytes2String(json))
 	if err == nil {
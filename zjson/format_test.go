@@ -40,3 +40,25 @@ func TestFormat(t *testing.T) {
 	str6 := Ugly(str5)
 	tt.Log(zstring.Bytes2String(str6))
 }
+
+func TestCompactIndent(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	raw := []byte("{\n  \"a\": 1,\n  \"b\": [1, 2]\n}")
+
+	b, err := Compact(raw)
+	tt.NoError(err)
+	tt.Equal(`{"a":1,"b":[1,2]}`, string(b))
+
+	b, err = Indent(b, "", "  ")
+	tt.NoError(err)
+	tt.Equal("{\n  \"a\": 1,\n  \"b\": [\n    1,\n    2\n  ]\n}", string(b))
+
+	b, err = Compact(b)
+	tt.NoError(err)
+	tt.Equal(`{"a":1,"b":[1,2]}`, string(b))
+
+	_, err = Compact([]byte(`{"a":`))
+	tt.EqualTrue(err != nil)
+	_, err = Indent([]byte(`{"a" 1}`), "", "  ")
+	tt.EqualTrue(err != nil)
+}
@@ -511,6 +511,21 @@ func ParseBytes(json []byte) *Res {
 	return Parse(zstring.Bytes2String(json))
 }
 
+// ParseArray parse a json array into its elements, each element references the input
+func ParseArray(json []byte) ([]*Res, error) {
+	if !Valid(zstring.Bytes2String(json)) {
+		return nil, ErrInvalidJSON
+	}
+	r := ParseBytes(json)
+	if !r.IsArray() {
+		return nil, ErrNotArray
+	}
+	if a := r.Array(); a != nil {
+		return a, nil
+	}
+	return []*Res{}, nil
+}
+
 func tonum(json string) (raw string, num float64) {
 	for i := 1; i < len(json); i++ {
 		if json[i] <= '-' {
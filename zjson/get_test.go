@@ -211,6 +211,34 @@ func TestGet(t *testing.T) {
 	tt.Log(parseData.Get("@reverse").String())
 }
 
+func TestParseArray(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	a, err := ParseArray([]byte(`[1, "two", true]`))
+	tt.NoError(err)
+	tt.Equal(3, len(a))
+	tt.Equal(1, a[0].Int())
+	tt.Equal("two", a[1].String())
+	tt.EqualTrue(a[2].Bool())
+
+	a, err = ParseArray([]byte(`[{"name":"zls","tags":["a","b"]},{"name":"go"}]`))
+	tt.NoError(err)
+	tt.Equal(2, len(a))
+	tt.Equal("zls", a[0].Get("name").String())
+	tt.Equal("b", a[0].Get("tags.1").String())
+	tt.Equal("go", a[1].Get("name").String())
+
+	a, err = ParseArray([]byte(`[]`))
+	tt.NoError(err)
+	tt.Equal(0, len(a))
+	tt.EqualTrue(a != nil)
+
+	_, err = ParseArray([]byte(`{"a":1}`))
+	tt.Equal(ErrNotArray, err)
+	_, err = ParseArray([]byte(`[1,`))
+	tt.Equal(ErrInvalidJSON, err)
+}
+
 func TestForEach(t *testing.T) {
 	tt := zlsgo.NewTest(t)
 	arr := Parse(`{"names":[{"name":1},{"name":2}],"values":[3,4]}`)
@@ -4,6 +4,7 @@ package zjson
 import (
 	jsongo "encoding/json"
 	"errors"
+	"reflect"
 	"strconv"
 	"unsafe"
 
@@ -17,6 +18,7 @@ var (
 	ErrNotAllowedWildcard    = errors.New("wildcard characters not allowed in path")
 	ErrNotAllowedArrayAccess = errors.New("array access character not allowed in path")
 	ErrTypeError             = errors.New("json must be an object or array")
+	ErrNotArray              = errors.New("json must be an array")
 )
 
 func (r *Res) MatchKeys(keys []string) *Res {
@@ -41,6 +43,27 @@ func (r *Res) Filter(fn func(key, value *Res) bool) *Res {
 	return Parse(j)
 }
 
+// Equal report whether two json documents hold the same data, ignoring key order and formatting
+func Equal(a, b []byte) (bool, error) {
+	var va, vb interface{}
+	if err := jsongo.Unmarshal(a, &va); err != nil {
+		return false, err
+	}
+	if err := jsongo.Unmarshal(b, &vb); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(va, vb), nil
+}
+
+// MustEqual same as Equal but panics if either document is invalid
+func MustEqual(a, b []byte) bool {
+	ok, err := Equal(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
 type stringHeader struct {
 	data unsafe.Pointer
 	len  int
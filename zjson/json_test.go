@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/sohaha/zlsgo"
 	"github.com/sohaha/zlsgo/zstring"
 )
 
@@ -45,3 +46,31 @@ func BenchmarkGolangUnmarshal(b *testing.B) {
 		_ = json.Unmarshal(demoByte, &demoData)
 	}
 }
+
+func TestEqual(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	ok, err := Equal([]byte(`{"a":1,"b":2}`), []byte(`{ "b": 2, "a": 1 }`))
+	tt.NoError(err)
+	tt.EqualTrue(ok)
+
+	ok, err = Equal([]byte(`{"a":1,"b":2}`), []byte(`{"a":1,"b":3}`))
+	tt.NoError(err)
+	tt.EqualTrue(!ok)
+
+	ok, err = Equal([]byte(`{"a":1}`), []byte(`{"a":"1"}`))
+	tt.NoError(err)
+	tt.EqualTrue(!ok)
+
+	tt.EqualTrue(MustEqual([]byte(`{"a":{"x":[1,{"y":true}],"z":null}}`), []byte(`{"a":{"z":null,"x":[1,{"y":true}]}}`)))
+	tt.EqualTrue(!MustEqual([]byte(`{"a":{"x":[1,2]}}`), []byte(`{"a":{"x":[2,1]}}`)))
+
+	_, err = Equal([]byte(`{"a":`), []byte(`{}`))
+	tt.EqualTrue(err != nil)
+	tt.Run("MustEqual panic", func(tt *zlsgo.TestUtil) {
+		defer func() {
+			tt.EqualTrue(recover() != nil)
+		}()
+		MustEqual([]byte(`{}`), []byte(`[`))
+	})
+}
@@ -0,0 +1,117 @@
+package zjson
+
+import (
+	"bytes"
+	jsongo "encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// RawNumber preserves the original representation of a JSON number
+type RawNumber string
+
+// String returns the literal text of the number
+func (n RawNumber) String() string {
+	return string(n)
+}
+
+// Int64 returns the number as an int64
+func (n RawNumber) Int64() (int64, error) {
+	return strconv.ParseInt(string(n), 10, 64)
+}
+
+// Float64 returns the number as a float64
+func (n RawNumber) Float64() (float64, error) {
+	return strconv.ParseFloat(string(n), 64)
+}
+
+func (n RawNumber) MarshalJSON() ([]byte, error) {
+	if n == "" {
+		return []byte("0"), nil
+	}
+	if !jsongo.Valid([]byte(n)) {
+		return nil, errors.New("invalid number literal " + strconv.Quote(string(n)))
+	}
+	return []byte(n), nil
+}
+
+func (n *RawNumber) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 1 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+
+	var num jsongo.Number
+	if err := jsongo.Unmarshal(data, &num); err != nil {
+		return err
+	}
+	*n = RawNumber(num)
+	return nil
+}
+
+// UnmarshalWithNumbers like json.Unmarshal, but numbers decoded into interface values become RawNumber
+func UnmarshalWithNumbers(data []byte, v interface{}) error {
+	d := jsongo.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+
+	convertNumberValue(reflect.ValueOf(v))
+	return nil
+}
+
+func convertNumbers(v interface{}) interface{} {
+	switch val := v.(type) {
+	case jsongo.Number:
+		return RawNumber(val)
+	case map[string]interface{}:
+		for k := range val {
+			val[k] = convertNumbers(val[k])
+		}
+	case []interface{}:
+		for i := range val {
+			val[i] = convertNumbers(val[i])
+		}
+	}
+	return v
+}
+
+func convertNumberValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			convertNumberValue(v.Elem())
+		}
+	case reflect.Interface:
+		if !v.IsNil() && v.CanSet() {
+			v.Set(reflect.ValueOf(convertNumbers(v.Interface())))
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() == reflect.Interface {
+			for _, k := range v.MapKeys() {
+				if e := v.MapIndex(k); !e.IsNil() {
+					v.SetMapIndex(k, reflect.ValueOf(convertNumbers(e.Interface())))
+				}
+			}
+			return
+		}
+		for _, k := range v.MapKeys() {
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			convertNumberValue(e)
+			v.SetMapIndex(k, e)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			convertNumberValue(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				convertNumberValue(f)
+			}
+		}
+	}
+}
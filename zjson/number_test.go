@@ -0,0 +1,74 @@
+package zjson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestNumber(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	var v interface{}
+	err := UnmarshalWithNumbers([]byte(`{"id":9007199254740993,"price":0.1000000000000000055511,"list":[1,2.5],"name":"zls"}`), &v)
+	tt.NoError(err)
+
+	m := v.(map[string]interface{})
+	id, ok := m["id"].(RawNumber)
+	tt.EqualTrue(ok)
+	i, err := id.Int64()
+	tt.NoError(err)
+	tt.Equal(int64(9007199254740993), i)
+
+	price := m["price"].(RawNumber)
+	tt.Equal("0.1000000000000000055511", price.String())
+	f, err := price.Float64()
+	tt.NoError(err)
+	tt.Equal(0.1, f)
+
+	list := m["list"].([]interface{})
+	tt.Equal(RawNumber("1"), list[0])
+	tt.Equal(RawNumber("2.5"), list[1])
+	tt.Equal("zls", m["name"])
+
+	_, err = list[1].(RawNumber).Int64()
+	tt.EqualTrue(err != nil)
+
+	j, err := json.Marshal(v)
+	tt.NoError(err)
+	tt.Equal(`{"id":9007199254740993,"list":[1,2.5],"name":"zls","price":0.1000000000000000055511}`, string(j))
+
+	var std interface{}
+	tt.NoError(json.Unmarshal([]byte(`{"id":1}`), &std))
+	tt.Equal(float64(1), std.(map[string]interface{})["id"])
+
+	tt.EqualTrue(UnmarshalWithNumbers([]byte(`{`), &v) != nil)
+}
+
+func TestNumberStruct(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	var s struct {
+		ID    RawNumber              `json:"id"`
+		Str   RawNumber              `json:"str"`
+		Any   interface{}            `json:"any"`
+		Items []interface{}          `json:"items"`
+		Extra map[string]interface{} `json:"extra"`
+	}
+	err := UnmarshalWithNumbers([]byte(`{"id":12345678901234567890,"str":"42","any":3,"items":[4],"extra":{"n":5}}`), &s)
+	tt.NoError(err)
+	tt.Equal(RawNumber("12345678901234567890"), s.ID)
+	tt.Equal(RawNumber("42"), s.Str)
+	tt.Equal(RawNumber("3"), s.Any)
+	tt.Equal(RawNumber("4"), s.Items[0])
+	tt.Equal(RawNumber("5"), s.Extra["n"])
+
+	var n RawNumber
+	tt.NoError(json.Unmarshal([]byte(`1.50`), &n))
+	tt.Equal("1.50", n.String())
+	tt.EqualTrue(json.Unmarshal([]byte(`"abc"`), &n) != nil)
+
+	_, err = json.Marshal(RawNumber("abc"))
+	tt.EqualTrue(err != nil)
+}
@@ -377,6 +377,12 @@ func SetBytes(json []byte, path string, value interface{}) ([]byte, error) {
 	return SetBytesOptions(json, path, value, nil)
 }
 
+// SetPath set value at a dot path such as "user.address.city" or "items.0.name",
+// only the changed part of json is rewritten, same as SetBytes
+func SetPath(json []byte, path string, value interface{}) ([]byte, error) {
+	return SetBytes(json, path, value)
+}
+
 func SetRaw(json, path, value string) (string, error) {
 	return SetRawOptions(json, path, value, nil)
 }
@@ -133,3 +133,26 @@ func BenchmarkSetBytes(b *testing.B) {
 		_, _ = SetBytes(json, strconv.Itoa(i), s)
 	}
 }
+
+func TestSetPath(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	data := []byte(`{"name":"a","user":{"address":{"city":"x"}},"items":[{"name":"i0"},{"name":"i1"}]}`)
+
+	b, err := SetPath(data, "name", "b")
+	tt.NoError(err)
+	tt.Equal(`{"name":"b","user":{"address":{"city":"x"}},"items":[{"name":"i0"},{"name":"i1"}]}`, string(b))
+
+	b, err = SetPath(data, "user.address.city", "y")
+	tt.NoError(err)
+	tt.Equal("y", GetBytes(b, "user.address.city").String())
+	tt.Equal("a", GetBytes(b, "name").String())
+
+	b, err = SetPath(data, "user.profile.age", 18)
+	tt.NoError(err)
+	tt.Equal(18, GetBytes(b, "user.profile.age").Int())
+	tt.Equal("x", GetBytes(b, "user.address.city").String())
+
+	b, err = SetPath(data, "items.1.name", "changed")
+	tt.NoError(err)
+	tt.Equal(`[{"name":"i0"},{"name":"changed"}]`, GetBytes(b, "items").String())
+}
@@ -0,0 +1,117 @@
+package zjson
+
+import (
+	jsongo "encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*jsongo.Marshaler)(nil)).Elem()
+
+// FromStruct convert a struct to a map keyed by its json tags,
+// honoring "-" and omitempty, nested structs become nested maps
+func FromStruct(v interface{}) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.New("value is a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("value must be a struct, got " + rv.Kind().String())
+	}
+
+	m := make(map[string]interface{}, rv.NumField())
+	structToMap(rv, m)
+	return m, nil
+}
+
+func structToMap(rv reflect.Value, m map[string]interface{}) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.IndexByte(tag, ','); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+		value := rv.Field(i)
+
+		if field.Anonymous && name == "" {
+			t := value
+			for t.Kind() == reflect.Ptr {
+				if t.IsNil() {
+					break
+				}
+				t = t.Elem()
+			}
+			if t.Kind() == reflect.Struct {
+				structToMap(t, m)
+				continue
+			}
+			if field.PkgPath != "" {
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(","+opts+",", ",omitempty,") && isEmptyValue(value) {
+			continue
+		}
+		m[name] = fieldValue(value)
+	}
+}
+
+func fieldValue(v reflect.Value) interface{} {
+	if v.Type().Implements(jsonMarshalerType) {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Elem().Kind() == reflect.Struct && !v.Elem().Type().Implements(jsonMarshalerType) {
+			return fieldValue(v.Elem())
+		}
+	case reflect.Struct:
+		if !reflect.PtrTo(v.Type()).Implements(jsonMarshalerType) {
+			m := make(map[string]interface{}, v.NumField())
+			structToMap(v, m)
+			return m
+		}
+		if v.CanAddr() {
+			return v.Addr().Interface()
+		}
+	}
+	return v.Interface()
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package zjson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestFromStruct(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	type (
+		Base struct {
+			ID int `json:"id"`
+		}
+		Profile struct {
+			City string `json:"city"`
+			Zip  string `json:"zip,omitempty"`
+		}
+		User struct {
+			Base
+			Name     string    `json:"name"`
+			Password string    `json:"-"`
+			Age      int       `json:"age,omitempty"`
+			Tags     []string  `json:"tags,omitempty"`
+			Profile  Profile   `json:"profile"`
+			Backup   *Profile  `json:"backup"`
+			Created  time.Time `json:"created"`
+			NoTag    bool
+			private  string
+		}
+	)
+
+	now := time.Now()
+	m, err := FromStruct(&User{
+		Base:     Base{ID: 1},
+		Name:     "zls",
+		Password: "secret",
+		Profile:  Profile{City: "sz"},
+		Backup:   &Profile{City: "gz", Zip: "510000"},
+		Created:  now,
+		private:  "x",
+	})
+	tt.NoError(err)
+	tt.Equal(1, m["id"])
+	tt.Equal("zls", m["name"])
+	tt.Equal(false, m["NoTag"])
+	tt.Equal(map[string]interface{}{"city": "sz"}, m["profile"])
+	tt.Equal(map[string]interface{}{"city": "gz", "zip": "510000"}, m["backup"])
+	tt.Equal(now, m["created"])
+	for _, k := range []string{"Password", "-", "age", "tags", "private"} {
+		_, ok := m[k]
+		tt.EqualTrue(!ok)
+	}
+
+	m, err = FromStruct(User{Age: 18})
+	tt.NoError(err)
+	tt.Equal(18, m["age"])
+	tt.Equal(nil, m["backup"])
+
+	_, err = FromStruct(1)
+	tt.EqualTrue(err != nil)
+	_, err = FromStruct((*User)(nil))
+	tt.EqualTrue(err != nil)
+}
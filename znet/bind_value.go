@@ -1,23 +1,125 @@
 package znet
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
 	"reflect"
+	"strings"
 
 	"github.com/sohaha/zlsgo/zjson"
 	"github.com/sohaha/zlsgo/zreflect"
 	"github.com/sohaha/zlsgo/ztype"
 )
 
-func (c *Context) Bind(obj interface{}) (err error) {
+// BindOption options for Bind, BindQueryWith and BindFormWith, set by WithTagName and WithFallback
+type BindOption struct {
+	// TagName struct tag used instead of z/json to look up query and form keys
+	TagName string
+	// Fallback bind from the query when the request body is empty, regardless of method
+	Fallback bool
+}
+
+// WithFallback bind from the query when the request body is empty
+func WithFallback() func(o *BindOption) {
+	return func(o *BindOption) {
+		o.Fallback = true
+	}
+}
+
+// WithTagName look up query and form keys in the given struct tag instead of z/json
+func WithTagName(name string) func(o *BindOption) {
+	return func(o *BindOption) {
+		o.TagName = name
+	}
+}
+
+func bindOption(opt []func(o *BindOption)) BindOption {
+	o := BindOption{}
+	for _, f := range opt {
+		f(&o)
+	}
+	return o
+}
+
+func (c *Context) Bind(obj interface{}, opt ...func(o *BindOption)) (err error) {
+	o := bindOption(opt)
 	method := c.Request.Method
 	if method == "GET" {
-		return c.BindQuery(obj)
+		return c.bindQuery(obj, o.TagName)
+	}
+	if o.Fallback {
+		if c.emptyBody() {
+			return c.bindQuery(obj, o.TagName)
+		}
 	}
 	contentType := c.ContentType()
 	if contentType == c.ContentType(ContentTypeJSON) {
 		return c.BindJSON(obj)
 	}
-	return c.BindForm(obj)
+	if contentType == mimeMultipartPOSTForm {
+		return c.bindMultipart(obj, o.TagName)
+	}
+	return c.bindForm(obj, o.TagName)
+}
+
+// emptyBody reports whether the request has no body, chunked bodies with an unknown
+// length are peeked and the consumed byte is put back
+func (c *Context) emptyBody() bool {
+	r := c.Request
+	if r.Body == nil || r.Body == http.NoBody {
+		return true
+	}
+	if c.rawData != nil {
+		return len(c.rawData) == 0
+	}
+	if r.ContentLength > 0 {
+		return false
+	}
+	var b [1]byte
+	n, _ := io.ReadFull(r.Body, b[:])
+	if n == 0 {
+		return true
+	}
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b[:n]), r.Body), r.Body}
+	return false
+}
+
+// BindError an error returned by ShouldBind or MustBind
+type BindError struct {
+	Err error
+}
+
+func (e *BindError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// ShouldBind like Bind, but wraps the error in *BindError and never writes the response
+func (c *Context) ShouldBind(obj interface{}, opt ...func(o *BindOption)) error {
+	if err := c.Bind(obj, opt...); err != nil {
+		return &BindError{Err: err}
+	}
+	return nil
+}
+
+// MustBind like ShouldBind, but on failure responds 400 through the engine error hook and aborts
+func (c *Context) MustBind(obj interface{}, opt ...func(o *BindOption)) error {
+	err := c.ShouldBind(obj, opt...)
+	if err != nil {
+		c.Engine.handleError(c, err, http.StatusBadRequest)
+		c.Abort()
+	}
+	return err
 }
 
 func (c *Context) BindJSON(obj interface{}) error {
@@ -29,18 +131,39 @@ func (c *Context) BindJSON(obj interface{}) error {
 }
 
 func (c *Context) BindQuery(obj interface{}) (err error) {
+	return c.bindQuery(obj, "")
+}
+
+// BindQueryWith bind the query, WithTagName selects the struct tag holding the keys
+func (c *Context) BindQueryWith(obj interface{}, opt ...func(o *BindOption)) error {
+	return c.bindQuery(obj, bindOption(opt).TagName)
+}
+
+func (c *Context) bindQuery(obj interface{}, tagName string) error {
+	m, err := c.queryBindMap(obj, tagName)
+	if err != nil {
+		return err
+	}
+	return ztype.ToStruct(m, obj)
+}
+
+func (c *Context) queryBindMap(obj interface{}, tagName string) (map[string]interface{}, error) {
 	q := c.GetAllQueryMaps()
 	typ := zreflect.TypeOf(obj)
 	m := make(map[string]interface{}, len(q))
-	err = zreflect.ForEach(typ, func(parent []string, index int, tag string, field reflect.StructField) error {
+	err := zreflect.ForEach(typ, func(parent []string, index int, tag string, field reflect.StructField) error {
+		key, ok := bindKey(field, tag, tagName)
+		if !ok {
+			return zreflect.SkipChild
+		}
 		kind := field.Type.Kind()
 		if kind == reflect.Struct {
-			m[tag] = c.QueryMap(tag)
+			m[tag] = c.QueryMap(key)
 		} else if kind == reflect.Slice {
-			v, _ := c.GetQueryArray(tag)
+			v, _ := c.GetQueryArray(key)
 			m[tag] = v
 		} else {
-			v, ok := q[tag]
+			v, ok := q[key]
 			if ok {
 				m[tag] = v
 			}
@@ -48,29 +171,49 @@ func (c *Context) BindQuery(obj interface{}) (err error) {
 
 		return zreflect.SkipChild
 	})
+	return m, err
+}
+
+func (c *Context) BindForm(obj interface{}) error {
+	return c.bindForm(obj, "")
+}
+
+// BindFormWith bind the post form, WithTagName selects the struct tag holding the keys
+func (c *Context) BindFormWith(obj interface{}, opt ...func(o *BindOption)) error {
+	return c.bindForm(obj, bindOption(opt).TagName)
+}
+
+func (c *Context) bindForm(obj interface{}, tagName string) error {
+	m, err := c.formBindMap(obj, tagName)
 	if err != nil {
 		return err
 	}
 	return ztype.ToStruct(m, obj)
 }
 
-func (c *Context) BindForm(obj interface{}) error {
+func (c *Context) formBindMap(obj interface{}, tagName string) (map[string]interface{}, error) {
 	q := c.GetPostFormAll()
 	typ := zreflect.TypeOf(obj)
 	m := make(map[string]interface{}, len(q))
 	err := zreflect.ForEach(typ, func(parent []string, index int, tag string, field reflect.StructField) error {
+		key, ok := bindKey(field, tag, tagName)
+		if !ok {
+			return zreflect.SkipChild
+		}
 		kind := field.Type.Kind()
 		if kind == reflect.Struct {
-			m[tag] = c.PostFormMap(tag)
+			m[tag] = c.PostFormMap(key)
 		} else if kind == reflect.Slice {
 			sliceTyp := field.Type.Elem().Kind()
 			if sliceTyp == reflect.Struct {
-				// TODO follow up support
+				if v, ok := c.getSliceMap(q, key); ok {
+					m[tag] = v
+				}
 			} else {
-				m[tag], _ = q[tag]
+				m[tag], _ = q[key]
 			}
 		} else {
-			v, ok := q[tag]
+			v, ok := q[key]
 			if ok {
 				m[tag] = v[0]
 			}
@@ -78,8 +221,216 @@ func (c *Context) BindForm(obj interface{}) error {
 
 		return zreflect.SkipChild
 	})
+	return m, err
+}
+
+// bindKey the request key of field, tag is its z/json name used by ztype.ToStruct
+func bindKey(field reflect.StructField, tag, tagName string) (string, bool) {
+	if tagName == "" {
+		return tag, true
+	}
+	name := field.Tag.Get(tagName)
+	if i := strings.IndexByte(name, ','); i >= 0 {
+		name = name[:i]
+	}
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		return tag, true
+	}
+	return name, true
+}
+
+var fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+
+// BindMultipart bind a multipart form, fields of type *multipart.FileHeader
+// or []*multipart.FileHeader receive the uploaded files
+func (c *Context) BindMultipart(obj interface{}) error {
+	return c.bindMultipart(obj, "")
+}
+
+func (c *Context) bindMultipart(obj interface{}, tagName string) error {
+	m, err := c.formBindMap(obj, tagName)
+	if err != nil {
+		return err
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(obj))
+	if rv.Kind() != reflect.Struct {
+		return ztype.ToStruct(m, obj)
+	}
+
+	files := make(map[int][]*multipart.FileHeader)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Type != fileHeaderType && (field.Type.Kind() != reflect.Slice || field.Type.Elem() != fileHeaderType) {
+			continue
+		}
+		tag, _ := zreflect.GetStructTag(field)
+		delete(m, tag)
+		key, ok := bindKey(field, tag, tagName)
+		if !ok {
+			continue
+		}
+		if fhs := form.File[key]; len(fhs) > 0 {
+			files[i] = fhs
+		}
+	}
+
+	if err = ztype.ToStruct(m, obj); err != nil {
+		return err
+	}
+
+	for i, fhs := range files {
+		f := rv.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		if f.Type() == fileHeaderType {
+			f.Set(reflect.ValueOf(fhs[0]))
+		} else {
+			f.Set(reflect.ValueOf(fhs))
+		}
+	}
+
+	return nil
+}
+
+// BindAll bind from path params, query and body, earlier sources take precedence
+func (c *Context) BindAll(obj interface{}) error {
+	m := make(map[string]interface{})
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		if c.ContentType() == c.ContentType(ContentTypeJSON) {
+			body, err := c.GetDataRawBytes()
+			if err != nil {
+				return err
+			}
+			if len(body) > 0 {
+				if err = zjson.Unmarshal(body, &m); err != nil {
+					return err
+				}
+			}
+		} else {
+			f, err := c.formBindMap(obj, "")
+			if err != nil {
+				return err
+			}
+			mergeBindMap(m, f)
+		}
+	}
+
+	q, err := c.queryBindMap(obj, "")
+	if err != nil {
+		return err
+	}
+	mergeBindMap(m, q)
+
+	for k, v := range c.GetAllParam() {
+		m[k] = v
+	}
+
+	return ztype.ToStruct(m, obj)
+}
+
+func mergeBindMap(dst, src map[string]interface{}) {
+	for k, v := range src {
+		rv := zreflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Invalid:
+			continue
+		case reflect.Map, reflect.Slice:
+			if rv.Len() == 0 {
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// BindHeader bind request headers to the fields tagged with header
+func (c *Context) BindHeader(obj interface{}) error {
+	m, err := tagBindMap(obj, "header", func(name string) ([]string, error) {
+		return c.Request.Header.Values(name), nil
+	})
+	if err != nil {
+		return err
+	}
+	return ztype.ToStruct(m, obj)
+}
+
+// BindPath bind route path params to the fields tagged with path
+func (c *Context) BindPath(obj interface{}) error {
+	params := c.GetAllParam()
+	m, err := tagBindMap(obj, "path", func(name string) ([]string, error) {
+		if v, ok := params[name]; ok {
+			return []string{v}, nil
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+	return ztype.ToStruct(m, obj)
+}
+
+// BindCookie bind request cookies to the fields tagged with cookie,
+// values are unescaped the same way as GetCookie
+func (c *Context) BindCookie(obj interface{}) error {
+	cookies := c.Request.Cookies()
+	m, err := tagBindMap(obj, "cookie", func(name string) ([]string, error) {
+		var v []string
+		for _, cookie := range cookies {
+			if cookie.Name != name {
+				continue
+			}
+			s, err := url.QueryUnescape(cookie.Value)
+			if err != nil {
+				return nil, fmt.Errorf("cookie %s: %w", name, err)
+			}
+			v = append(v, s)
+		}
+		return v, nil
+	})
 	if err != nil {
 		return err
 	}
 	return ztype.ToStruct(m, obj)
 }
+
+// tagBindMap collect the values of fields tagged with tagName, keyed for ztype.ToStruct
+func tagBindMap(obj interface{}, tagName string, get func(name string) ([]string, error)) (map[string]interface{}, error) {
+	typ := zreflect.TypeOf(obj)
+	m := make(map[string]interface{})
+	err := zreflect.ForEach(typ, func(parent []string, index int, tag string, field reflect.StructField) error {
+		name := field.Tag.Get(tagName)
+		if i := strings.IndexByte(name, ','); i >= 0 {
+			name = name[:i]
+		}
+		if name == "" || name == "-" {
+			return zreflect.SkipChild
+		}
+
+		v, err := get(name)
+		if err != nil {
+			return err
+		}
+		if len(v) == 0 {
+			return zreflect.SkipChild
+		}
+		if field.Type.Kind() == reflect.Slice {
+			m[tag] = v
+		} else {
+			m[tag] = v[0]
+		}
+
+		return zreflect.SkipChild
+	})
+	return m, err
+}
@@ -1,6 +1,12 @@
 package znet
 
 import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/sohaha/zlsgo"
@@ -60,3 +66,342 @@ func TestContext_BindForm(t *testing.T) {
 		t.Logf("%+v\n", s)
 	})
 }
+
+func TestContext_BindFormSliceStruct(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := newServer()
+
+	type item struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	type form struct {
+		Items []item   `json:"items"`
+		Tags  []string `json:"tags"`
+	}
+
+	for name, v := range map[string]struct {
+		body  string
+		items []item
+		tags  []string
+	}{
+		"simple":   {`items[0][name]=foo&items[0][age]=1&items[1][name]=bar`, []item{{"foo", 1}, {"bar", 0}}, nil},
+		"disorder": {`items[1][name]=bar&items[0][age]=1&items[0][name]=foo`, []item{{"foo", 1}, {"bar", 0}}, nil},
+		"gaps":     {`items[5][name]=baz&items[0][name]=foo&items[2][age]=3`, []item{{"foo", 0}, {"", 3}, {"baz", 0}}, nil},
+		"scalar":   {`tags=a&tags=b&items[0][name]=foo`, []item{{"foo", 0}}, []string{"a", "b"}},
+	} {
+		v := v
+		path := "/TestContext_BindFormSliceStruct/" + name
+		_ = newRequest(r, "POST", []string{path, v.body, mimePOSTForm}, path, func(c *Context) {
+			var f form
+			tt.NoError(c.BindForm(&f))
+			tt.Equal(v.items, f.Items)
+			tt.Equal(v.tags, f.Tags)
+		})
+	}
+}
+
+func TestContext_BindAll(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestContext_BindAll")
+
+	type all struct {
+		ID    int      `json:"id"`
+		Name  string   `json:"name"`
+		Age   int      `json:"age"`
+		Email string   `json:"email"`
+		Tags  []string `json:"tags"`
+	}
+	var s all
+	r.POST("/user/:id", func(c *Context) {
+		s = all{}
+		tt.NoError(c.BindAll(&s))
+	})
+
+	_ = request(r, "POST", "/user/1?id=2&name=query&tags=a&tags=b", strings.NewReader(`{"id":3,"name":"body","age":18}`), func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.Header.Set("Content-Type", mimeJSON)
+	})
+	tt.Equal(all{ID: 1, Name: "query", Age: 18, Tags: []string{"a", "b"}}, s)
+
+	_ = request(r, "POST", "/user/5?age=20", strings.NewReader(`name=form&age=30&email=a@b.c`), func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.Header.Set("Content-Type", mimePOSTForm)
+	})
+	tt.Equal(all{ID: 5, Name: "form", Age: 20, Email: "a@b.c"}, s)
+}
+
+func TestContext_BindHeader(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestContext_BindHeader")
+
+	type header struct {
+		RequestID string   `header:"X-Request-Id"`
+		Retry     int      `header:"X-Retry"`
+		Tags      []string `header:"X-Tag"`
+		Missing   string   `header:"X-Missing"`
+		Name      string   `json:"name"`
+	}
+	var s header
+	r.GET("/", func(c *Context) {
+		s = header{Name: "keep"}
+		tt.NoError(c.BindHeader(&s))
+	})
+
+	_ = request(r, "GET", "/", nil, func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.Header.Set("X-Request-Id", "abc")
+		req.Header.Set("X-Retry", "3")
+		req.Header.Add("X-Tag", "a")
+		req.Header.Add("X-Tag", "b")
+		req.Header.Set("Name", "ignored")
+	})
+	tt.Equal(header{RequestID: "abc", Retry: 3, Tags: []string{"a", "b"}, Name: "keep"}, s)
+}
+
+func TestContext_BindWithFallback(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestContext_BindWithFallback")
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	var s user
+	r.Any("/", func(c *Context) {
+		s = user{}
+		tt.NoError(c.Bind(&s, WithFallback()))
+	})
+
+	_ = request(r, "PUT", "/?name=query", strings.NewReader(`{"name":"json","age":18}`), func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.Header.Set("Content-Type", mimeJSON)
+	})
+	tt.Equal(user{Name: "json", Age: 18}, s)
+
+	_ = request(r, "PUT", "/?name=query&age=20", nil, func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.Header.Set("Content-Type", mimeJSON)
+	})
+	tt.Equal(user{Name: "query", Age: 20}, s)
+
+	_ = request(r, "PATCH", "/?name=query", strings.NewReader(""))
+	tt.Equal(user{Name: "query"}, s)
+
+	_ = request(r, "PUT", "/?name=chunked", ioutil.NopCloser(strings.NewReader("")), func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.ContentLength = -1
+		req.Header.Set("Content-Type", mimeJSON)
+	})
+	tt.Equal(user{Name: "chunked"}, s)
+
+	_ = request(r, "PUT", "/?name=query", ioutil.NopCloser(strings.NewReader(`{"name":"json"}`)), func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.ContentLength = -1
+		req.Header.Set("Content-Type", mimeJSON)
+	})
+	tt.Equal(user{Name: "json"}, s)
+
+	_ = request(r, "GET", "/?name=get&age=1", nil)
+	tt.Equal(user{Name: "get", Age: 1}, s)
+
+	_ = request(r, "POST", "/?name=query", strings.NewReader(`name=form&age=30`), func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.Header.Set("Content-Type", mimePOSTForm)
+	})
+	tt.Equal(user{Name: "form", Age: 30}, s)
+}
+
+func TestContext_BindCookie(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestContext_BindCookie")
+
+	type cookie struct {
+		Session string   `cookie:"session"`
+		UID     int      `cookie:"uid"`
+		Prefs   []string `cookie:"pref"`
+		Missing string   `cookie:"missing"`
+		Name    string   `json:"name"`
+	}
+	var (
+		s   cookie
+		err error
+	)
+	r.GET("/", func(c *Context) {
+		s = cookie{Name: "keep"}
+		err = c.BindCookie(&s)
+	})
+
+	_ = request(r, "GET", "/", nil, func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.AddCookie(&http.Cookie{Name: "session", Value: "a%20b"})
+		req.AddCookie(&http.Cookie{Name: "uid", Value: "42"})
+		req.AddCookie(&http.Cookie{Name: "pref", Value: "dark"})
+		req.AddCookie(&http.Cookie{Name: "pref", Value: "compact"})
+		req.AddCookie(&http.Cookie{Name: "name", Value: "ignored"})
+	})
+	tt.NoError(err)
+	tt.Equal(cookie{Session: "a b", UID: 42, Prefs: []string{"dark", "compact"}, Name: "keep"}, s)
+
+	_ = request(r, "GET", "/", nil, func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.AddCookie(&http.Cookie{Name: "session", Value: "%zz"})
+	})
+	tt.EqualTrue(err != nil)
+}
+
+func TestContext_BindMultipart(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestContext_BindMultipart")
+
+	type upload struct {
+		Avatar *multipart.FileHeader   `json:"avatar"`
+		Name   string                  `json:"name"`
+		Docs   []*multipart.FileHeader `json:"docs"`
+		Tags   []string                `json:"tags"`
+		Age    int                     `json:"age"`
+	}
+	var (
+		s   upload
+		err error
+	)
+	r.POST("/", func(c *Context) {
+		s = upload{}
+		err = c.Bind(&s)
+	})
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	_ = mw.WriteField("name", "zlsgo")
+	_ = mw.WriteField("age", "18")
+	_ = mw.WriteField("tags", "a")
+	_ = mw.WriteField("tags", "b")
+	fw, _ := mw.CreateFormFile("avatar", "avatar.png")
+	_, _ = fw.Write([]byte("png"))
+	fw, _ = mw.CreateFormFile("docs", "a.txt")
+	_, _ = fw.Write([]byte("a"))
+	fw, _ = mw.CreateFormFile("docs", "b.txt")
+	_, _ = fw.Write([]byte("b"))
+	_ = mw.Close()
+
+	_ = request(r, "POST", "/", body, func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+	})
+	tt.NoError(err)
+	tt.Equal("zlsgo", s.Name)
+	tt.Equal(18, s.Age)
+	tt.Equal([]string{"a", "b"}, s.Tags)
+	tt.EqualTrue(s.Avatar != nil)
+	tt.Equal("avatar.png", s.Avatar.Filename)
+	tt.Equal(2, len(s.Docs))
+	tt.Equal("b.txt", s.Docs[1].Filename)
+
+	body.Reset()
+	mw = multipart.NewWriter(body)
+	_ = mw.WriteField("name", "nofile")
+	_ = mw.Close()
+	_ = request(r, "POST", "/", body, func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+	})
+	tt.NoError(err)
+	tt.Equal("nofile", s.Name)
+	tt.EqualTrue(s.Avatar == nil)
+	tt.Equal(0, len(s.Docs))
+}
+
+func TestContext_BindPath(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestContext_BindPath")
+
+	type path struct {
+		ID      int    `path:"id"`
+		Slug    string `path:"slug"`
+		Missing string `path:"missing"`
+		Name    string `json:"name"`
+	}
+	var s path
+	r.GET("/users/:id/:slug", func(c *Context) {
+		s = path{Name: "keep"}
+		tt.NoError(c.BindPath(&s))
+	})
+
+	_ = request(r, "GET", "/users/42/hello?name=query", nil)
+	tt.Equal(path{ID: 42, Slug: "hello", Name: "keep"}, s)
+}
+
+func TestContext_BindWithTagName(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestContext_BindWithTagName")
+
+	type search struct {
+		Keyword string   `json:"keyword" form:"q"`
+		Page    int      `json:"page" form:"p"`
+		Tags    []string `json:"tags" form:"t"`
+		Size    int      `json:"size"`
+		Skip    string   `json:"skip" form:"-"`
+	}
+	var s search
+	r.GET("/query", func(c *Context) {
+		s = search{}
+		tt.NoError(c.BindQueryWith(&s, WithTagName("form")))
+	})
+	r.POST("/form", func(c *Context) {
+		s = search{}
+		tt.NoError(c.BindFormWith(&s, WithTagName("form")))
+	})
+	r.POST("/bind", func(c *Context) {
+		s = search{}
+		tt.NoError(c.Bind(&s, WithTagName("form"), WithFallback()))
+	})
+
+	_ = request(r, "GET", "/query?q=zlsgo&p=2&t=a&t=b&size=10&skip=1&keyword=no", nil)
+	tt.Equal(search{Keyword: "zlsgo", Page: 2, Tags: []string{"a", "b"}, Size: 10}, s)
+
+	_ = newRequest(r, "POST", []string{"/form", `q=zlsgo&p=3&size=20&skip=1`, mimePOSTForm}, "")
+	tt.Equal(search{Keyword: "zlsgo", Page: 3, Size: 20}, s)
+
+	_ = newRequest(r, "POST", []string{"/bind", `q=bind&t=x`, mimePOSTForm}, "")
+	tt.Equal(search{Keyword: "bind", Tags: []string{"x"}}, s)
+}
+
+func TestContext_ShouldBind(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestContext_ShouldBind")
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	var (
+		s    user
+		err  error
+		next bool
+	)
+	r.POST("/should", func(c *Context) {
+		s = user{}
+		err = c.ShouldBind(&s)
+	})
+	r.POST("/must", func(c *Context) {
+		c.String(200, "ok")
+	}, func(c *Context) {
+		next = false
+		s = user{}
+		if err = c.MustBind(&s); err != nil {
+			return
+		}
+		next = true
+		c.Next()
+	})
+
+	w := newRequest(r, "POST", []string{"/should", `{"name":"zls"}`, mimeJSON}, "")
+	tt.NoError(err)
+	tt.Equal("zls", s.Name)
+	tt.Equal(200, w.Code)
+
+	w = newRequest(r, "POST", []string{"/should", `not json`, mimeJSON}, "")
+	_, ok := err.(*BindError)
+	tt.EqualTrue(ok)
+	tt.Equal(200, w.Code)
+	tt.Equal("", w.Body.String())
+
+	w = newRequest(r, "POST", []string{"/must", `{"name":"zls"}`, mimeJSON}, "")
+	tt.NoError(err)
+	tt.EqualTrue(next)
+	tt.Equal("ok", w.Body.String())
+
+	w = newRequest(r, "POST", []string{"/must", `not json`, mimeJSON}, "")
+	_, ok = err.(*BindError)
+	tt.EqualTrue(ok)
+	tt.EqualTrue(!next)
+	tt.Equal(400, w.Code)
+	tt.Equal(err.Error(), w.Body.String())
+}
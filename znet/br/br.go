@@ -0,0 +1,19 @@
+package br
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/andybalholm/brotli"
+	"github.com/sohaha/zlsgo/znet"
+)
+
+// Decoder decode br request bodies, register it with znet.WithDecoder("br", br.Decoder)
+func Decoder(r io.Reader) (io.ReadCloser, error) {
+	return ioutil.NopCloser(brotli.NewReader(r)), nil
+}
+
+// Decompress register Decoder on znet.DecompressRequest
+func Decompress() func(o *znet.DecompressOption) {
+	return znet.WithDecoder("br", Decoder)
+}
@@ -0,0 +1,40 @@
+package br_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	zls "github.com/sohaha/zlsgo"
+	"github.com/sohaha/zlsgo/znet"
+	"github.com/sohaha/zlsgo/znet/br"
+)
+
+func TestDecompress(t *testing.T) {
+	tt := zls.NewTest(t)
+	r := znet.New("TestBrDecompress")
+	r.SetMode(znet.ProdMode)
+	r.Use(znet.DecompressRequest(br.Decompress()))
+	r.POST("/", func(c *znet.Context) error {
+		body, err := c.GetDataRaw()
+		if err != nil {
+			return err
+		}
+		c.String(200, body)
+		return nil
+	})
+
+	var buf bytes.Buffer
+	bw := brotli.NewWriter(&buf)
+	_, _ = bw.Write([]byte("br body"))
+	_ = bw.Close()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/", bytes.NewReader(buf.Bytes()))
+	req.Header.Set("Content-Encoding", "br")
+	r.ServeHTTP(w, req)
+	tt.Equal(200, w.Code)
+	tt.Equal("br body", w.Body.String())
+}
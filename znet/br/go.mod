@@ -0,0 +1,17 @@
+module github.com/sohaha/zlsgo/znet/br
+
+go 1.18
+
+require (
+	github.com/andybalholm/brotli v1.1.1
+	github.com/sohaha/zlsgo v0.0.0-00010101000000-000000000000
+)
+
+require (
+	golang.org/x/crypto v0.17.0 // indirect
+	golang.org/x/exp v0.0.0-20230213192124-5e25df0256eb // indirect
+	golang.org/x/sync v0.1.0 // indirect
+	golang.org/x/sys v0.15.0 // indirect
+)
+
+replace github.com/sohaha/zlsgo => ../../
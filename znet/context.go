@@ -40,6 +40,11 @@ func (c *Context) CompletionLink(link string) string {
 	return finalLink
 }
 
+// Route the pattern of the matched route, such as /user/:id, empty when no route matched
+func (c *Context) Route() string {
+	return c.route
+}
+
 // IsWebsocket Is Websocket
 func (c *Context) IsWebsocket() bool {
 	if strings.Contains(strings.ToLower(c.GetHeader("Connection")), "upgrade") &&
@@ -59,6 +64,20 @@ func (c *Context) IsAjax() bool {
 	return c.GetHeader("X-Requested-With") == "XMLHttpRequest"
 }
 
+// WantsJSON the Accept header asks for json, a bare wildcard does not count
+func (c *Context) WantsJSON() bool {
+	for _, v := range strings.Split(c.GetHeader("Accept"), ",") {
+		if i := strings.IndexByte(v, ';'); i >= 0 {
+			v = v[:i]
+		}
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == mimeJSON || strings.HasSuffix(v, "+json") {
+			return true
+		}
+	}
+	return false
+}
+
 // GetClientIP Client IP
 func (c *Context) GetClientIP() (IP string) {
 	IP = ClientPublicIP(c.Request)
@@ -73,18 +92,34 @@ func (c *Context) GetHeader(key string) string {
 	return c.Request.Header.Get(key)
 }
 
-// SetHeader Set Header
+// SetHeader Set Header, replacing any existing values, empty value deletes the header
 func (c *Context) SetHeader(key, value string) {
 	key = textproto.CanonicalMIMEHeaderKey(key)
 	c.mu.Lock()
 	if value == "" {
 		delete(c.header, key)
 	} else {
-		c.header[key] = append(c.header[key], value)
+		c.header[key] = []string{value}
 	}
 	c.mu.Unlock()
 }
 
+// AddHeader Add Header, appending to any existing values
+func (c *Context) AddHeader(key, value string) {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	c.mu.Lock()
+	c.header[key] = append(c.header[key], value)
+	c.mu.Unlock()
+}
+
+// DeleteHeader Delete Header
+func (c *Context) DeleteHeader(key string) {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	c.mu.Lock()
+	delete(c.header, key)
+	c.mu.Unlock()
+}
+
 func (c *Context) write() {
 	if !c.done.CAS(false, true) {
 		return
@@ -95,16 +130,7 @@ func (c *Context) write() {
 	data := c.PrevContent()
 	// data.Code.CAS(0, http.StatusInternalServerError)
 
-	for key, value := range c.header {
-		for i := range value {
-			header := value[i]
-			if i == 0 {
-				c.Writer.Header().Set(key, header)
-			} else {
-				c.Writer.Header().Add(key, header)
-			}
-		}
-	}
+	c.flushHeader()
 
 	if c.Request == nil || c.Request.Context().Err() != nil {
 		return
@@ -121,10 +147,16 @@ func (c *Context) write() {
 		code = http.StatusOK
 		data.Code.Store(int32(code))
 	}
+	if c.streamed {
+		return
+	}
 	size := len(data.Content)
 	if size > 0 {
 		c.Writer.Header().Set("Content-Length", strconv.Itoa(size))
 		c.Writer.WriteHeader(code)
+		if c.Request.Method == http.MethodHead {
+			return
+		}
 		_, err := c.Writer.Write(data.Content)
 		if err != nil {
 			c.Log.Error(err)
@@ -136,6 +168,19 @@ func (c *Context) write() {
 	}
 }
 
+func (c *Context) flushHeader() {
+	for key, value := range c.header {
+		for i := range value {
+			header := value[i]
+			if i == 0 {
+				c.Writer.Header().Set(key, header)
+			} else {
+				c.Writer.Header().Add(key, header)
+			}
+		}
+	}
+}
+
 // Next middleware, if current middleware has been stopped, it will return false
 func (c *Context) Next() bool {
 	for {
@@ -228,6 +273,16 @@ func (c *Context) WithValue(key string, value interface{}) *Context {
 	return c
 }
 
+// AllowedMethods get the methods registered for the path of a 405 request
+func (c *Context) AllowedMethods() []string {
+	if v, ok := c.Value(allowedMethodsKey); ok {
+		if methods, ok := v.([]string); ok {
+			return methods
+		}
+	}
+	return nil
+}
+
 // Value get context sharing data
 func (c *Context) Value(key string, def ...interface{}) (value interface{}, ok bool) {
 	c.mu.RLock()
@@ -0,0 +1,123 @@
+package znet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestHeader(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestHeader")
+
+	r.Use(func(c *Context) {
+		c.Next()
+		c.SetHeader("X-After", "after")
+	})
+	r.GET("/", func(c *Context) {
+		c.SetHeader("x-single", "1")
+		c.SetHeader("X-Single", "2")
+		c.AddHeader("X-Multi", "1")
+		c.AddHeader("x-multi", "2")
+		c.SetHeader("X-Delete", "1")
+		c.DeleteHeader("x-delete")
+		c.SetHeader("X-Empty", "1")
+		c.SetHeader("X-Empty", "")
+		c.String(200, "ok")
+	})
+	r.GET("/written", func(c *Context) {
+		c.Writer.WriteHeader(201)
+		c.SetHeader("X-Late", "1")
+	})
+
+	w := request(r, "GET", "/", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal([]string{"2"}, w.Header().Values("X-Single"))
+	tt.Equal([]string{"1", "2"}, w.Header().Values("X-Multi"))
+	tt.Equal("", w.Header().Get("X-Delete"))
+	tt.Equal("", w.Header().Get("X-Empty"))
+	tt.Equal("after", w.Header().Get("X-After"))
+
+	w = request(r, "GET", "/written", nil)
+	tt.Equal(201, w.Code)
+	tt.Equal("", w.Result().Header.Get("X-Late"))
+}
+
+func TestIsWebsocketAndSSE(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestIsWebsocketAndSSE")
+	r.GET("/", func(c *Context) {
+		c.String(200, strconv.FormatBool(c.IsWebsocket())+","+strconv.FormatBool(c.IsSSE()))
+	})
+
+	w := request(r, "GET", "/", nil, func(w *httptest.ResponseRecorder, r *http.Request) {
+		r.Header.Set("Connection", "keep-alive, Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+	})
+	tt.Equal("true,false", w.Body.String())
+
+	w = request(r, "GET", "/", nil, func(w *httptest.ResponseRecorder, r *http.Request) {
+		r.Header.Set("Accept", "text/event-stream")
+	})
+	tt.Equal("false,true", w.Body.String())
+
+	w = request(r, "GET", "/", nil)
+	tt.Equal("false,false", w.Body.String())
+}
+
+func TestIsAjaxAndWantsJSON(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestIsAjaxAndWantsJSON")
+	r.GET("/", func(c *Context) {
+		c.String(200, strconv.FormatBool(c.IsAjax())+","+strconv.FormatBool(c.WantsJSON()))
+	})
+
+	for accept, expected := range map[string]string{
+		"":                                  "false,false",
+		"*/*":                               "false,false",
+		"text/html, */*;q=0.8":              "false,false",
+		"application/json":                  "false,true",
+		"text/html, application/json;q=0.9": "false,true",
+		"application/vnd.api+json":          "false,true",
+	} {
+		w := request(r, "GET", "/", nil, func(w *httptest.ResponseRecorder, r *http.Request) {
+			r.Header.Set("Accept", accept)
+		})
+		tt.Equal(expected, w.Body.String())
+	}
+
+	w := request(r, "GET", "/", nil, func(w *httptest.ResponseRecorder, r *http.Request) {
+		r.Header.Set("X-Requested-With", "XMLHttpRequest")
+		r.Header.Set("Accept", "application/json, text/javascript, */*; q=0.01")
+	})
+	tt.Equal("true,true", w.Body.String())
+}
+
+func TestSetContentType(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tt.Equal("text/plain; charset=utf-8", ContentTypePlain)
+	tt.Equal("text/html; charset=utf-8", ContentTypeHTML)
+	tt.Equal("application/json; charset=utf-8", ContentTypeJSON)
+	tt.Equal("application/xml; charset=utf-8", ContentTypeXML)
+	tt.Equal("application/x-www-form-urlencoded", ContentTypeForm)
+
+	r := New("TestSetContentType")
+	r.GET("/", func(c *Context) {
+		c.SetContentType(ContentTypeXML).String(200, "<a/>")
+	})
+	r.GET("/written", func(c *Context) {
+		c.Writer.Header().Set("Content-Type", ContentTypeHTML)
+		c.Writer.WriteHeader(200)
+		c.SetContentType(ContentTypeJSON)
+	})
+
+	w := request(r, "GET", "/", nil)
+	tt.Equal(ContentTypeXML, w.Header().Get("Content-Type"))
+	tt.Equal("<a/>", w.Body.String())
+
+	w = request(r, "GET", "/written", nil)
+	tt.Equal(ContentTypeHTML, w.Result().Header.Get("Content-Type"))
+}
@@ -94,3 +94,27 @@ func TestDefault(t *testing.T) {
 	tt.Equal(0, w.Body.Len())
 
 }
+
+func TestAutoOptions(t *testing.T) {
+	tt := zls.NewTest(t)
+	r := znet.New("TestCorsAutoOptions")
+	r.SetMode(znet.ProdMode)
+	r.AutoOptions()
+	r.Use(cors.Default())
+	r.GET("/auto", func(c *znet.Context) {})
+	r.POST("/auto", func(c *znet.Context) {})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("OPTIONS", "/auto", nil)
+	req.Header.Add("Origin", "https://qq.com")
+	r.ServeHTTP(w, req)
+	tt.Equal(http.StatusNoContent, w.Code)
+	tt.Equal("https://qq.com", w.Header().Get("Access-Control-Allow-Origin"))
+	tt.EqualTrue(w.Header().Get("Access-Control-Allow-Methods") != "")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("OPTIONS", "/auto", nil)
+	r.ServeHTTP(w, req)
+	tt.Equal(http.StatusNoContent, w.Code)
+	tt.Equal("GET, POST, OPTIONS", w.Header().Get("Allow"))
+}
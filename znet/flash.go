@@ -0,0 +1,126 @@
+package znet
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+const (
+	flashCookieName = "zlsgo_flash"
+	flashValueKey   = "__zlsgo_flash__"
+)
+
+var defaultFlashSecret = func() []byte {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return b
+}()
+
+// SetFlashSecret set the key used to sign flash cookies,
+// a random key is generated per process by default
+func (e *Engine) SetFlashSecret(secret []byte) {
+	e.flashSecret = secret
+}
+
+// RedirectWithFlash redirect to url and keep a one-time message for the next request
+func (c *Context) RedirectWithFlash(code int, url string, key string, value interface{}) error {
+	m := c.flashes()
+	m[key] = value
+	if err := c.writeFlash(m); err != nil {
+		delete(m, key)
+		return err
+	}
+	c.Redirect(url, int32(code))
+	return nil
+}
+
+// Flash get a flash message, the message is deleted after the first read.
+// Values go through JSON, so numbers are read back as float64
+func (c *Context) Flash(key string) (interface{}, bool) {
+	m := c.flashes()
+	v, ok := m[key]
+	if !ok {
+		return nil, false
+	}
+	delete(m, key)
+	_ = c.writeFlash(m)
+	return v, true
+}
+
+func (c *Context) flashes() map[string]interface{} {
+	if v, ok := c.Value(flashValueKey); ok {
+		return v.(map[string]interface{})
+	}
+
+	m := make(map[string]interface{})
+	if cookie, err := c.Request.Cookie(flashCookieName); err == nil {
+		if data, ok := c.Engine.verifyFlash(cookie.Value); ok {
+			_ = json.Unmarshal(data, &m)
+		}
+	}
+	c.WithValue(flashValueKey, m)
+	return m
+}
+
+func (c *Context) writeFlash(m map[string]interface{}) error {
+	cookie := &http.Cookie{
+		Name:     flashCookieName,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   -1,
+	}
+	if len(m) > 0 {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		cookie.Value = c.Engine.signFlash(data)
+		cookie.MaxAge = 0
+	}
+
+	header := c.Writer.Header()
+	cookies := header["Set-Cookie"][:0]
+	for _, v := range header["Set-Cookie"] {
+		if !strings.HasPrefix(v, flashCookieName+"=") {
+			cookies = append(cookies, v)
+		}
+	}
+	header["Set-Cookie"] = append(cookies, cookie.String())
+	return nil
+}
+
+func (e *Engine) flashKey() []byte {
+	if len(e.flashSecret) > 0 {
+		return e.flashSecret
+	}
+	return defaultFlashSecret
+}
+
+func (e *Engine) signFlash(data []byte) string {
+	mac := hmac.New(sha256.New, e.flashKey())
+	mac.Write(data)
+	return base64.RawURLEncoding.EncodeToString(data) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (e *Engine) verifyFlash(value string) ([]byte, bool) {
+	i := strings.LastIndexByte(value, '.')
+	if i < 0 {
+		return nil, false
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value[:i])
+	if err != nil {
+		return nil, false
+	}
+	sum, err := base64.RawURLEncoding.DecodeString(value[i+1:])
+	if err != nil {
+		return nil, false
+	}
+	mac := hmac.New(sha256.New, e.flashKey())
+	mac.Write(data)
+	return data, hmac.Equal(sum, mac.Sum(nil))
+}
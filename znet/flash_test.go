@@ -0,0 +1,81 @@
+package znet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestFlash(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestFlash")
+	r.SetFlashSecret([]byte("secret"))
+	r.GET("/login", func(c *Context) error {
+		return c.RedirectWithFlash(302, "/home", "msg", "hello")
+	})
+	r.GET("/multi", func(c *Context) error {
+		_ = c.RedirectWithFlash(302, "/home", "a", "1")
+		return c.RedirectWithFlash(302, "/home", "b", 2)
+	})
+	r.GET("/home", func(c *Context) {
+		key := c.DefaultQuery("key", "msg")
+		v, ok := c.Flash(key)
+		if !ok {
+			c.String(404, "")
+			return
+		}
+		_, again := c.Flash(key)
+		tt.EqualTrue(!again)
+		c.JSON(200, v)
+	})
+
+	withCookies := func(cookies []*http.Cookie) func(w *httptest.ResponseRecorder, req *http.Request) {
+		return func(w *httptest.ResponseRecorder, req *http.Request) {
+			for _, v := range cookies {
+				req.AddCookie(v)
+			}
+		}
+	}
+
+	tt.Run("redirect", func(tt *zlsgo.TestUtil) {
+		w := request(r, "GET", "/login", nil)
+		tt.Equal(302, w.Code)
+		tt.Equal("http://"+host+"/home", w.Header().Get("Location"))
+		cookies := w.Result().Cookies()
+		tt.Equal(1, len(cookies))
+
+		w = request(r, "GET", "/home", nil, withCookies(cookies))
+		tt.Equal(200, w.Code)
+		tt.Equal(`"hello"`, w.Body.String())
+
+		expired := w.Result().Cookies()
+		tt.Equal(1, len(expired))
+		tt.EqualTrue(expired[0].MaxAge < 0)
+
+		w = request(r, "GET", "/home", nil, withCookies(expired))
+		tt.Equal(404, w.Code)
+	})
+
+	tt.Run("multiple", func(tt *zlsgo.TestUtil) {
+		w := request(r, "GET", "/multi", nil)
+		cookies := w.Result().Cookies()
+		tt.Equal(1, len(cookies))
+
+		w = request(r, "GET", "/home?key=a", nil, withCookies(cookies))
+		tt.Equal(`"1"`, w.Body.String())
+		cookies = w.Result().Cookies()
+
+		w = request(r, "GET", "/home?key=b", nil, withCookies(cookies))
+		tt.Equal(`2`, w.Body.String())
+	})
+
+	tt.Run("tampered", func(tt *zlsgo.TestUtil) {
+		w := request(r, "GET", "/login", nil)
+		cookies := w.Result().Cookies()
+		cookies[0].Value = "e30." + cookies[0].Value[len(cookies[0].Value)-10:]
+		w = request(r, "GET", "/home", nil, withCookies(cookies))
+		tt.Equal(404, w.Code)
+	})
+}
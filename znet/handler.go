@@ -1,8 +1,13 @@
 package znet
 
 import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -31,7 +36,14 @@ func (h invokerCodeText) Invoke(_ []interface{}) ([]reflect.Value, error) {
 
 func defErrorHandler() ErrHandlerFunc {
 	return func(c *Context, err error) {
-		c.String(500, err.Error())
+		code := http.StatusInternalServerError
+		var bindErr *BindError
+		if errors.Is(err, ErrRequestBodyTooLarge) {
+			code = http.StatusRequestEntityTooLarge
+		} else if errors.As(err, &bindErr) {
+			code = http.StatusBadRequest
+		}
+		c.Engine.handleError(c, err, code)
 	}
 }
 
@@ -43,8 +55,14 @@ func RewriteErrorHandler(handler ErrHandlerFunc) Handler {
 	}
 }
 
-// Recovery is a middleware that recovers from panics anywhere in the chain
+// Recovery is a middleware that recovers from panics anywhere in the chain,
+// a nil handler responds through the engine error hook
 func Recovery(handler ErrHandlerFunc) Handler {
+	if handler == nil {
+		handler = func(c *Context, err error) {
+			c.Engine.handleError(c, err, http.StatusInternalServerError)
+		}
+	}
 	return func(c *Context) {
 		defer func() {
 			if err := recover(); err != nil {
@@ -59,6 +77,135 @@ func Recovery(handler ErrHandlerFunc) Handler {
 	}
 }
 
+type decompressBody struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (b *decompressBody) Close() error {
+	var err error
+	for i := len(b.closers) - 1; i >= 0; i-- {
+		if e := b.closers[i].Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// ErrRequestBodyTooLarge is returned when reading a decompressed body beyond DecompressOption.MaxBytes,
+// handler errors wrapping it are answered with 413
+var ErrRequestBodyTooLarge = errors.New("request body too large")
+
+type (
+	// Decoder wrap a compressed request body for one Content-Encoding
+	Decoder func(r io.Reader) (io.ReadCloser, error)
+	// DecompressOption options for DecompressRequest
+	DecompressOption struct {
+		// Decoders by lower case Content-Encoding, gzip, x-gzip and deflate are registered by default
+		Decoders map[string]Decoder
+		// MaxBytes maximum size of the decompressed body, defaults to 32MB, negative disables the limit
+		MaxBytes int64
+	}
+)
+
+// WithDecoder register a decoder for a Content-Encoding such as br on DecompressRequest
+func WithDecoder(encoding string, decoder Decoder) func(o *DecompressOption) {
+	return func(o *DecompressOption) {
+		o.Decoders[strings.ToLower(encoding)] = decoder
+	}
+}
+
+func gzipDecoder(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// deflateDecoder accept zlib wrapped bodies and raw RFC 1951 streams, which many clients send
+func deflateDecoder(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	if h, err := br.Peek(2); err == nil && h[0]&0x0f == 8 && (uint16(h[0])<<8|uint16(h[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
+
+type maxBytesBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (b *maxBytesBody) Read(p []byte) (int, error) {
+	if b.n < 0 {
+		return 0, ErrRequestBodyTooLarge
+	}
+	if int64(len(p)) > b.n+1 {
+		p = p[:b.n+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.n {
+		n, err = int(b.n), ErrRequestBodyTooLarge
+	}
+	b.n -= int64(n)
+	if err == ErrRequestBodyTooLarge {
+		b.n = -1
+	}
+	return n, err
+}
+
+// DecompressRequest is a middleware that decompresses gzip or deflate request bodies, and any
+// encoding registered with WithDecoder, according to the Content-Encoding header,
+// unsupported encodings are rejected with 415, reading past MaxBytes fails with ErrRequestBodyTooLarge
+func DecompressRequest(opt ...func(o *DecompressOption)) HandlerFunc {
+	o := DecompressOption{MaxBytes: 32 << 20, Decoders: map[string]Decoder{
+		"gzip":    gzipDecoder,
+		"x-gzip":  gzipDecoder,
+		"deflate": deflateDecoder,
+	}}
+	for _, f := range opt {
+		f(&o)
+	}
+	return func(c *Context) {
+		encoding := c.GetHeader("Content-Encoding")
+		if encoding == "" || c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		encodings := strings.Split(encoding, ",")
+		body := &decompressBody{Reader: c.Request.Body, closers: []io.Closer{c.Request.Body}}
+		for i := len(encodings) - 1; i >= 0; i-- {
+			name := strings.ToLower(strings.TrimSpace(encodings[i]))
+			if name == "identity" || name == "" {
+				continue
+			}
+			decoder, ok := o.Decoders[name]
+			if !ok || decoder == nil {
+				_ = body.Close()
+				c.Engine.handleError(c, errors.New("unsupported content encoding: "+encodings[i]), http.StatusUnsupportedMediaType)
+				c.Abort()
+				return
+			}
+			r, err := decoder(body.Reader)
+			if err != nil {
+				_ = body.Close()
+				c.Engine.handleError(c, err, http.StatusBadRequest)
+				c.Abort()
+				return
+			}
+			body.Reader = r
+			body.closers = append(body.closers, r)
+		}
+
+		c.Request.Body = body
+		if o.MaxBytes >= 0 {
+			c.Request.Body = &maxBytesBody{ReadCloser: body, n: o.MaxBytes}
+		}
+		c.Request.ContentLength = -1
+		c.Request.Header.Del("Content-Encoding")
+		c.Request.Header.Del("Content-Length")
+		c.Next()
+	}
+}
+
 func requestLog(c *Context) {
 	if c.Engine.IsDebug() {
 		var status string
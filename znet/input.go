@@ -9,6 +9,9 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/sohaha/zlsgo/zfile"
@@ -85,6 +88,23 @@ func (c *Context) GetQueryArray(key string) ([]string, bool) {
 	return []string{}, false
 }
 
+// GetQueryArrayExpanded Get Query Array, comma separated values are split and empty values skipped
+func (c *Context) GetQueryArrayExpanded(key string) ([]string, bool) {
+	values, ok := c.GetQueryArray(key)
+	if !ok {
+		return values, false
+	}
+	res := make([]string, 0, len(values))
+	for i := range values {
+		for _, v := range strings.Split(values[i], ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				res = append(res, v)
+			}
+		}
+	}
+	return res, len(res) > 0
+}
+
 // GetQuery Get Query
 func (c *Context) GetQuery(key string) (string, bool) {
 	if values, ok := c.GetQueryArray(key); ok {
@@ -93,6 +113,56 @@ func (c *Context) GetQuery(key string) (string, bool) {
 	return "", false
 }
 
+// QueryData typed accessors for query values
+type QueryData struct {
+	c *Context
+}
+
+// Query typed query helper
+func (c *Context) Query() *QueryData {
+	return &QueryData{c: c}
+}
+
+// GetString Get Query string or default
+func (q *QueryData) GetString(key string, def string) string {
+	if v, ok := q.c.GetQuery(key); ok {
+		return v
+	}
+	return def
+}
+
+// GetInt Get Query int or default
+func (q *QueryData) GetInt(key string, def int) int {
+	if v, ok := q.c.GetQuery(key); ok {
+		return parseInt(v, def)
+	}
+	return def
+}
+
+// GetFloat Get Query float64 or default
+func (q *QueryData) GetFloat(key string, def float64) float64 {
+	if v, ok := q.c.GetQuery(key); ok {
+		return parseFloat(v, def)
+	}
+	return def
+}
+
+// GetBool Get Query bool or default
+func (q *QueryData) GetBool(key string, def bool) bool {
+	if v, ok := q.c.GetQuery(key); ok {
+		return parseBool(v, def)
+	}
+	return def
+}
+
+// GetSlice Get Query array or default, comma separated values are split
+func (q *QueryData) GetSlice(key string, def []string) []string {
+	if v, ok := q.c.GetQueryArrayExpanded(key); ok {
+		return v
+	}
+	return def
+}
+
 // DefaultQuery Get Query Or Default
 func (c *Context) DefaultQuery(key string, def string) string {
 	if value, ok := c.GetQuery(key); ok {
@@ -158,6 +228,77 @@ func (c *Context) GetPostFormAll() (value url.Values) {
 	return
 }
 
+// FormData typed accessors for post form values
+type FormData struct {
+	c *Context
+}
+
+// Form typed post form helper
+func (c *Context) Form() *FormData {
+	return &FormData{c: c}
+}
+
+// GetString Get PostForm string or default
+func (f *FormData) GetString(key string, def string) string {
+	if v, ok := f.c.GetPostForm(key); ok {
+		return v
+	}
+	return def
+}
+
+// GetInt Get PostForm int or default
+func (f *FormData) GetInt(key string, def int) int {
+	if v, ok := f.c.GetPostForm(key); ok {
+		return parseInt(v, def)
+	}
+	return def
+}
+
+// GetFloat Get PostForm float64 or default
+func (f *FormData) GetFloat(key string, def float64) float64 {
+	if v, ok := f.c.GetPostForm(key); ok {
+		return parseFloat(v, def)
+	}
+	return def
+}
+
+// GetBool Get PostForm bool or default
+func (f *FormData) GetBool(key string, def bool) bool {
+	if v, ok := f.c.GetPostForm(key); ok {
+		return parseBool(v, def)
+	}
+	return def
+}
+
+// parseInt parse a base 10 integer, empty or invalid values return def
+func parseInt(v string, def int) int {
+	i, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return def
+	}
+	return i
+}
+
+// parseFloat parse a float, empty or invalid values return def
+func parseFloat(v string, def float64) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return def
+	}
+	return f
+}
+
+// parseBool accepts true/1/yes/on and false/0/no/off case-insensitively, anything else returns def
+func parseBool(v string, def bool) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "true", "1", "yes", "on":
+		return true
+	case "false", "0", "no", "off":
+		return false
+	}
+	return def
+}
+
 // PostFormMap PostForm Map
 func (c *Context) PostFormMap(key string) map[string]string {
 	v, _ := c.GetPostFormMap(key)
@@ -244,6 +385,49 @@ func (c *Context) get(m map[string][]string, key string) (map[string]string, boo
 	return d, e
 }
 
+func (c *Context) getSliceMap(m map[string][]string, key string) ([]map[string]string, bool) {
+	items := make(map[int]map[string]string)
+	prefix := key + "["
+	for k, v := range m {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		rest := k[len(prefix):]
+		i := strings.IndexByte(rest, ']')
+		if i < 1 {
+			continue
+		}
+		idx, err := strconv.Atoi(rest[:i])
+		if err != nil || idx < 0 {
+			continue
+		}
+		rest = rest[i+1:]
+		if len(rest) < 3 || rest[0] != '[' || rest[len(rest)-1] != ']' {
+			continue
+		}
+		item, ok := items[idx]
+		if !ok {
+			item = make(map[string]string)
+			items[idx] = item
+		}
+		item[rest[1:len(rest)-1]] = v[0]
+	}
+	if len(items) == 0 {
+		return nil, false
+	}
+
+	indexes := make([]int, 0, len(items))
+	for idx := range items {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+	d := make([]map[string]string, 0, len(indexes))
+	for _, idx := range indexes {
+		d = append(d, items[idx])
+	}
+	return d, true
+}
+
 // FormFile FormFile
 func (c *Context) FormFile(name string) (*multipart.FileHeader, error) {
 	f, err := c.FormFiles(name)
@@ -284,7 +468,7 @@ func (c *Context) MultipartForm() (*multipart.Form, error) {
 	return c.Request.MultipartForm, err
 }
 
-// SaveUploadedFile Save Uploaded File
+// SaveUploadedFile Save Uploaded File, missing parent directories are created
 func (c *Context) SaveUploadedFile(file *multipart.FileHeader, dist string) error {
 	src, err := file.Open()
 	if err != nil {
@@ -293,6 +477,9 @@ func (c *Context) SaveUploadedFile(file *multipart.FileHeader, dist string) erro
 	defer src.Close()
 
 	dist = zfile.RealPath(dist)
+	if err = os.MkdirAll(filepath.Dir(dist), os.ModePerm); err != nil {
+		return err
+	}
 	out, err := os.Create(dist)
 	if err != nil {
 		return err
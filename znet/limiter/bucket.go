@@ -0,0 +1,129 @@
+package limiter
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sohaha/zlsgo/znet"
+)
+
+// BucketOption token bucket limiter options
+type BucketOption struct {
+	// Overflow called when a request is rejected, defaults to 429
+	Overflow func(c *znet.Context)
+	// IdleTTL buckets not used for this long are removed, defaults to 10 minutes
+	IdleTTL time.Duration
+}
+
+type bucket struct {
+	last   time.Time
+	tokens float64
+}
+
+type bucketStore struct {
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	rate      float64
+	burst     float64
+	ttl       time.Duration
+	mu        sync.Mutex
+}
+
+// RateLimiter token bucket limiter keyed by client IP,
+// rate is the number of tokens refilled per second
+func RateLimiter(rate float64, burst int, opt ...func(o *BucketOption)) znet.HandlerFunc {
+	return RateLimiterByKey(func(c *znet.Context) string {
+		return c.GetClientIP()
+	}, rate, burst, opt...)
+}
+
+// RateLimiterByKey token bucket limiter keyed by keyFn
+func RateLimiterByKey(keyFn func(c *znet.Context) string, rate float64, burst int, opt ...func(o *BucketOption)) znet.HandlerFunc {
+	o := BucketOption{
+		IdleTTL: 10 * time.Minute,
+		Overflow: func(c *znet.Context) {
+			c.String(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests))
+		},
+	}
+	for _, f := range opt {
+		f(&o)
+	}
+	s := newBucketStore(rate, burst, o.IdleTTL)
+	return func(c *znet.Context) {
+		ok, wait := s.take(keyFn(c), time.Now())
+		if !ok {
+			c.SetHeader("Retry-After", strconv.Itoa(retryAfter(wait)))
+			o.Overflow(c)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func newBucketStore(rate float64, burst int, ttl time.Duration) *bucketStore {
+	if burst < 1 {
+		burst = 1
+	}
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+	return &bucketStore{
+		buckets:   make(map[string]*bucket),
+		rate:      rate,
+		burst:     float64(burst),
+		ttl:       ttl,
+		lastSweep: time.Now(),
+	}
+}
+
+// take consumes a token for key, returning how long to wait when none is left
+func (s *bucketStore) take(key string, now time.Time) (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= s.ttl {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: s.burst, last: now}
+		s.buckets[key] = b
+	} else {
+		if s.rate > 0 {
+			b.tokens = math.Min(s.burst, b.tokens+now.Sub(b.last).Seconds()*s.rate)
+		}
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	if s.rate <= 0 {
+		return false, s.ttl
+	}
+	return false, time.Duration((1 - b.tokens) / s.rate * float64(time.Second))
+}
+
+func (s *bucketStore) sweep(now time.Time) {
+	for k, b := range s.buckets {
+		if now.Sub(b.last) >= s.ttl {
+			delete(s.buckets, k)
+		}
+	}
+	s.lastSweep = now
+}
+
+func retryAfter(wait time.Duration) int {
+	sec := int(math.Ceil(wait.Seconds()))
+	if sec < 1 {
+		sec = 1
+	}
+	return sec
+}
@@ -0,0 +1,43 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestBucketStore(tt *testing.T) {
+	t := zlsgo.NewTest(tt)
+	now := time.Now()
+	s := newBucketStore(2, 2, time.Minute)
+	s.lastSweep = now
+
+	ok, _ := s.take("a", now)
+	t.EqualTrue(ok)
+	ok, _ = s.take("a", now)
+	t.EqualTrue(ok)
+	ok, wait := s.take("a", now)
+	t.EqualTrue(!ok)
+	t.Equal(500*time.Millisecond, wait)
+	t.Equal(1, retryAfter(wait))
+
+	ok, _ = s.take("a", now.Add(500*time.Millisecond))
+	t.EqualTrue(ok)
+
+	ok, _ = s.take("b", now.Add(30*time.Second))
+	t.EqualTrue(ok)
+	t.Equal(2, len(s.buckets))
+
+	s.take("b", now.Add(70*time.Second))
+	t.Equal(1, len(s.buckets))
+	_, exist := s.buckets["a"]
+	t.EqualTrue(!exist)
+
+	s = newBucketStore(0, 1, time.Minute)
+	ok, _ = s.take("a", now)
+	t.EqualTrue(ok)
+	ok, wait = s.take("a", now.Add(time.Second))
+	t.EqualTrue(!ok)
+	t.Equal(60, retryAfter(wait))
+}
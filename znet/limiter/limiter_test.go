@@ -112,3 +112,47 @@ func run(url string, r *znet.Engine) int64 {
 	wg.Wait()
 	return ii
 }
+
+func TestRateLimiter(tt *testing.T) {
+	t := zlsgo.NewTest(tt)
+	r := znet.New("limiter_bucket_test")
+
+	r.GET("/bucket", func(c *znet.Context) {
+		c.String(200, "ok")
+	}, limiter.RateLimiter(10, 2))
+
+	r.GET("/bucketKey", func(c *znet.Context) {
+		c.String(200, "ok")
+	}, limiter.RateLimiterByKey(func(c *znet.Context) string {
+		return c.GetHeader("X-User")
+	}, 1, 1, func(o *limiter.BucketOption) {
+		o.Overflow = func(c *znet.Context) {
+			c.String(http.StatusTooManyRequests, "slow down")
+		}
+	}))
+
+	do := func(url, user string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+		req.Header.Set("X-Real-Ip", "192.168.1.2")
+		req.Header.Set("X-User", user)
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Equal(http.StatusOK, do("/bucket", "").Code)
+	t.Equal(http.StatusOK, do("/bucket", "").Code)
+	w := do("/bucket", "")
+	t.Equal(http.StatusTooManyRequests, w.Code)
+	t.Equal("1", w.Header().Get("Retry-After"))
+
+	time.Sleep(150 * time.Millisecond)
+	t.Equal(http.StatusOK, do("/bucket", "").Code)
+
+	t.Equal(http.StatusOK, do("/bucketKey", "a").Code)
+	t.Equal(http.StatusOK, do("/bucketKey", "b").Code)
+	w = do("/bucketKey", "a")
+	t.Equal(http.StatusTooManyRequests, w.Code)
+	t.Equal("slow down", w.Body.String())
+	t.Equal("1", w.Header().Get("Retry-After"))
+}
@@ -0,0 +1,61 @@
+package znet
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// Span the part of a tracing span used by OtelMiddleware
+	Span interface {
+		SetAttributes(attrs map[string]interface{})
+		End()
+	}
+	// Tracer starts a span and returns the context carrying it,
+	// use znet/otel.Tracer to drive it with an OpenTelemetry trace.Tracer
+	Tracer interface {
+		Start(ctx context.Context, name string) (context.Context, Span)
+	}
+)
+
+// OtelMiddleware start a span named "HTTP METHOD /route" for every request,
+// znet/otel.New builds it from an OpenTelemetry trace.Tracer,
+// the route is the matched pattern rather than the raw path,
+// the span is carried by c.Request.Context() and ended after the handlers
+func OtelMiddleware(tracer Tracer) HandlerFunc {
+	return func(c *Context) {
+		if tracer == nil {
+			c.Next()
+			return
+		}
+
+		method, route := c.Request.Method, c.Route()
+		name := "HTTP " + method
+		if route != "" {
+			name += " " + route
+		}
+		ctx, span := tracer.Start(c.Request.Context(), name)
+		if span == nil {
+			c.Next()
+			return
+		}
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		code := c.PrevContent().Code.Load()
+		if code == 0 {
+			code = http.StatusOK
+		}
+		attrs := map[string]interface{}{
+			"http.request.method":       method,
+			"http.response.status_code": int(code),
+			"client.address":            c.GetClientIP(),
+		}
+		if route != "" {
+			attrs["http.route"] = route
+		}
+		span.SetAttributes(attrs)
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/sohaha/zlsgo/znet/otel
+
+go 1.18
+
+require (
+	github.com/sohaha/zlsgo v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.14.0
+	go.opentelemetry.io/otel/trace v1.14.0
+)
+
+require (
+	golang.org/x/crypto v0.17.0 // indirect
+	golang.org/x/exp v0.0.0-20230213192124-5e25df0256eb // indirect
+	golang.org/x/sync v0.1.0 // indirect
+	golang.org/x/sys v0.15.0 // indirect
+)
+
+replace github.com/sohaha/zlsgo => ../../
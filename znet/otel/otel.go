@@ -0,0 +1,65 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sohaha/zlsgo/znet"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	tracer struct {
+		t trace.Tracer
+	}
+	span struct {
+		s trace.Span
+	}
+)
+
+// New znet.OtelMiddleware backed by an OpenTelemetry tracer
+func New(t trace.Tracer) znet.HandlerFunc {
+	return znet.OtelMiddleware(Tracer(t))
+}
+
+// Tracer adapt an OpenTelemetry tracer to znet.Tracer, spans are started as server spans
+// and 5xx responses set the span status to error
+func Tracer(t trace.Tracer) znet.Tracer {
+	if t == nil {
+		return nil
+	}
+	return &tracer{t: t}
+}
+
+func (o *tracer) Start(ctx context.Context, name string) (context.Context, znet.Span) {
+	ctx, s := o.t.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer))
+	return ctx, &span{s: s}
+}
+
+func (o *span) End() {
+	o.s.End()
+}
+
+func (o *span) SetAttributes(attrs map[string]interface{}) {
+	kv := make([]attribute.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		switch val := v.(type) {
+		case string:
+			kv = append(kv, attribute.String(k, val))
+		case int:
+			kv = append(kv, attribute.Int(k, val))
+			if k == "http.response.status_code" && val >= 500 {
+				o.s.SetStatus(codes.Error, "")
+			}
+		case int64:
+			kv = append(kv, attribute.Int64(k, val))
+		case bool:
+			kv = append(kv, attribute.Bool(k, val))
+		default:
+			kv = append(kv, attribute.String(k, fmt.Sprint(val)))
+		}
+	}
+	o.s.SetAttributes(kv...)
+}
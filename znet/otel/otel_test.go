@@ -0,0 +1,89 @@
+package otel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	zls "github.com/sohaha/zlsgo"
+	"github.com/sohaha/zlsgo/znet"
+	"github.com/sohaha/zlsgo/znet/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	testTracer struct {
+		spans []*testSpan
+	}
+	testSpan struct {
+		trace.Span
+		name   string
+		kind   trace.SpanKind
+		attrs  map[attribute.Key]attribute.Value
+		status codes.Code
+		ended  bool
+	}
+)
+
+func (t *testTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	s := &testSpan{Span: trace.SpanFromContext(ctx), name: name, kind: cfg.SpanKind(), attrs: map[attribute.Key]attribute.Value{}}
+	t.spans = append(t.spans, s)
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+func (s *testSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, v := range kv {
+		s.attrs[v.Key] = v.Value
+	}
+}
+
+func (s *testSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func (s *testSpan) End(...trace.SpanEndOption) {
+	s.ended = true
+}
+
+func TestNew(t *testing.T) {
+	tt := zls.NewTest(t)
+	tr := &testTracer{}
+
+	r := znet.New("TestOtelNew")
+	r.SetMode(znet.ProdMode)
+	r.Use(otel.New(tr))
+	r.GET("/user/:id", func(c *znet.Context) {
+		tt.EqualTrue(trace.SpanFromContext(c.Request.Context()) == trace.Span(tr.spans[0]))
+		c.String(200, "ok")
+	})
+	r.GET("/fail", func(c *znet.Context) {
+		c.String(500, "fail")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/user/1", nil)
+	r.ServeHTTP(w, req)
+	tt.Equal(200, w.Code)
+
+	tt.Equal(1, len(tr.spans))
+	s := tr.spans[0]
+	tt.Equal("HTTP GET /user/:id", s.name)
+	tt.Equal(trace.SpanKindServer, s.kind)
+	tt.EqualTrue(s.ended)
+	tt.Equal("GET", s.attrs["http.request.method"].AsString())
+	tt.Equal("/user/:id", s.attrs["http.route"].AsString())
+	tt.Equal(int64(200), s.attrs["http.response.status_code"].AsInt64())
+	tt.Equal(codes.Unset, s.status)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/fail", nil)
+	r.ServeHTTP(w, req)
+	tt.Equal(2, len(tr.spans))
+	tt.Equal(codes.Error, tr.spans[1].status)
+
+	tt.EqualTrue(otel.Tracer(nil) == nil)
+}
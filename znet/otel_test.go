@@ -0,0 +1,114 @@
+package znet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+type (
+	testSpanKey struct{}
+	testSpan    struct {
+		name  string
+		attrs map[string]interface{}
+		ended bool
+	}
+	testTracer struct {
+		spans []*testSpan
+	}
+)
+
+func (s *testSpan) SetAttributes(attrs map[string]interface{}) {
+	s.attrs = attrs
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &testSpan{name: name}
+	t.spans = append(t.spans, s)
+	return context.WithValue(ctx, testSpanKey{}, s), s
+}
+
+func TestOtelMiddlewareSpan(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tr := &testTracer{}
+
+	r := New("TestOtelMiddlewareSpan")
+	r.SetMode(ProdMode)
+	r.Use(OtelMiddleware(tr))
+	r.GET("/user/:id", func(c *Context) {
+		s, ok := c.Request.Context().Value(testSpanKey{}).(*testSpan)
+		tt.EqualTrue(ok)
+		tt.Equal("HTTP GET /user/:id", s.name)
+		c.String(201, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/user/1", nil)
+	r.ServeHTTP(w, req)
+	tt.Equal(201, w.Code)
+	tt.Equal("ok", w.Body.String())
+
+	tt.Equal(1, len(tr.spans))
+	s := tr.spans[0]
+	tt.Equal("HTTP GET /user/:id", s.name)
+	tt.EqualTrue(s.ended)
+	tt.Equal(201, s.attrs["http.response.status_code"])
+	tt.Equal("GET", s.attrs["http.request.method"])
+	tt.Equal("/user/:id", s.attrs["http.route"])
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/none", nil)
+	r.ServeHTTP(w, req)
+	tt.Equal(404, w.Code)
+	tt.Equal(2, len(tr.spans))
+	s = tr.spans[1]
+	tt.Equal("HTTP GET", s.name)
+	tt.Equal(404, s.attrs["http.response.status_code"])
+	_, ok := s.attrs["http.route"]
+	tt.EqualTrue(!ok)
+}
+
+func TestOtelMiddleware(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tr := &testTracer{}
+
+	r := New("TestOtelMiddleware")
+	r.SetMode(ProdMode)
+	r.Use(OtelMiddleware(tr))
+	r.POST("/post/:id/comments", func(c *Context) {
+		tt.Equal("/post/:id/comments", c.Route())
+		c.String(200, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/post/7/comments", nil)
+	r.ServeHTTP(w, req)
+	tt.Equal(200, w.Code)
+	tt.Equal(1, len(tr.spans))
+	tt.Equal("HTTP POST /post/:id/comments", tr.spans[0].name)
+	tt.Equal("/post/:id/comments", tr.spans[0].attrs["http.route"])
+}
+
+func TestOtelMiddlewareNilTracer(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	r := New("TestOtelMiddlewareNil")
+	r.SetMode(ProdMode)
+	r.Use(OtelMiddleware(nil))
+	r.GET("/", func(c *Context) {
+		c.String(200, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	r.ServeHTTP(w, req)
+	tt.Equal(200, w.Code)
+	tt.Equal("ok", w.Body.String())
+}
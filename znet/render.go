@@ -3,8 +3,10 @@ package znet
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"html/template"
+	"io"
 	"io/ioutil"
 	"mime"
 	"net/http"
@@ -35,6 +37,11 @@ type (
 		Data        interface{}
 		ContentDate []byte
 	}
+	renderXML struct {
+		Data        interface{}
+		ContentDate []byte
+		Header      bool
+	}
 	renderFile struct {
 		Data        string
 		ContentDate []byte
@@ -69,6 +76,10 @@ var (
 	ContentTypeHTML = "text/html; charset=utf-8"
 	// ContentTypeJSON json
 	ContentTypeJSON = "application/json; charset=utf-8"
+	// ContentTypeXML xml
+	ContentTypeXML = "application/xml; charset=utf-8"
+	// ContentTypeForm urlencoded form
+	ContentTypeForm = "application/x-www-form-urlencoded"
 )
 
 func (c *Context) renderProcessing(code int32, r render) {
@@ -109,8 +120,56 @@ func (r *renderJSON) Content(c *Context) []byte {
 	if r.ContentDate != nil {
 		return r.ContentDate
 	}
+	marshal := json.Marshal
+	if c.Engine != nil && c.Engine.jsonMarshaler != nil {
+		marshal = c.Engine.jsonMarshaler
+	}
+	content, err := marshal(r.Data)
+	if err != nil {
+		r.ContentDate = c.renderFailed(err)
+		return r.ContentDate
+	}
 	c.SetContentType(ContentTypeJSON)
-	r.ContentDate, _ = json.Marshal(r.Data)
+	r.ContentDate = content
+	return r.ContentDate
+}
+
+// renderFailed answer a render error through the engine error hook with 500, without a hook
+// or when the hook output fails too the error is logged and a generic 500 is sent
+func (c *Context) renderFailed(err error) []byte {
+	c.Log.Error(err)
+	if !c.renderFailing && c.Engine != nil && c.Engine.onError != nil {
+		c.renderFailing = true
+		c.mu.RLock()
+		failed := c.render
+		c.mu.RUnlock()
+		c.Engine.onError(c, err, http.StatusInternalServerError)
+		c.mu.RLock()
+		r := c.render
+		c.mu.RUnlock()
+		if r != nil && r != failed {
+			return r.Content(c)
+		}
+	}
+	c.prevData.Code.Store(http.StatusInternalServerError)
+	c.SetContentType(ContentTypePlain)
+	return zstring.String2Bytes(http.StatusText(http.StatusInternalServerError))
+}
+
+func (r *renderXML) Content(c *Context) []byte {
+	if r.ContentDate != nil {
+		return r.ContentDate
+	}
+	content, err := xml.Marshal(r.Data)
+	if err != nil {
+		r.ContentDate = c.renderFailed(err)
+		return r.ContentDate
+	}
+	c.SetContentType(ContentTypeXML)
+	if r.Header {
+		content = append([]byte(xml.Header), content...)
+	}
+	r.ContentDate = content
 	return r.ContentDate
 }
 
@@ -208,6 +267,55 @@ func (c *Context) JSON(code int32, values interface{}) {
 	c.renderProcessing(code, &renderJSON{Data: values})
 }
 
+// StreamJSON encode v straight to the response instead of buffering it, the status set
+// by SetStatus or Abort is sent with the first byte and defaults to 200.
+// A custom marshaler set by SetJSONMarshaler is still honored, but it buffers the whole value.
+// An error after the first byte is still returned, the truncated response is not replaced by an error response
+func (c *Context) StreamJSON(v interface{}) error {
+	c.SetContentType(ContentTypeJSON)
+	c.flushHeader()
+
+	w := &firstWriteWriter{w: c.Writer, first: func() {
+		c.streamed = true
+		code := int(c.prevData.Code.Load())
+		if code == 0 {
+			code = http.StatusOK
+			c.prevData.Code.Store(int32(code))
+		}
+		c.Writer.WriteHeader(code)
+	}}
+
+	if c.Engine != nil && c.Engine.jsonMarshaler != nil {
+		b, err := c.Engine.jsonMarshaler(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(v)
+}
+
+// firstWriteWriter run first once before the first Write
+type firstWriteWriter struct {
+	w     io.Writer
+	first func()
+}
+
+func (w *firstWriteWriter) Write(p []byte) (int, error) {
+	if w.first != nil {
+		w.first()
+		w.first = nil
+	}
+	return w.w.Write(p)
+}
+
+// XML export xml, withHeader prepends the standard xml declaration
+func (c *Context) XML(code int32, values interface{}, withHeader ...bool) {
+	c.renderProcessing(code, &renderXML{Data: values, Header: len(withHeader) > 0 && withHeader[0]})
+}
+
 // ApiJSON ApiJSON
 func (c *Context) ApiJSON(code int32, msg string, data interface{}) {
 	c.renderProcessing(http.StatusOK, &renderJSON{Data: ApiData{Code: code, Data: data,
@@ -11,6 +11,8 @@ import (
 	"github.com/sohaha/zlsgo/zfile"
 )
 
+const allowedMethodsKey = "__zlsgo_allowed_methods__"
+
 var (
 	// ErrGenerateParameters is returned when generating a route withRequestLog wrong parameters.
 	ErrGenerateParameters = errors.New("params contains wrong parameters")
@@ -24,6 +26,9 @@ var (
 	// ErrPatternGrammar is returned when generating a route that pattern grammar error.
 	ErrPatternGrammar = errors.New("pattern grammar error")
 
+	// ErrMethodNotAllowed is passed to the error hook by the default method not allowed handler.
+	ErrMethodNotAllowed = errors.New("405 method not allowed")
+
 	methods = map[string]struct{}{
 		http.MethodGet:     {},
 		http.MethodPost:    {},
@@ -35,6 +40,17 @@ var (
 		http.MethodConnect: {},
 		http.MethodTrace:   {},
 	}
+
+	autoOptionsMethods = []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+		http.MethodConnect,
+		http.MethodTrace,
+	}
 )
 
 type (
@@ -64,16 +80,7 @@ func (e *Engine) StaticFS(relativePath string, fs http.FileSystem, moreHandler .
 	log := temporarilyTurnOffTheLog(e, routeLog(e.Log, f, "FILE", ap))
 	fileServer := http.StripPrefix(ap, http.FileServer(fs))
 	handler := func(c *Context) {
-		for key, value := range c.header {
-			for i := range value {
-				header := value[i]
-				if i == 0 {
-					c.Writer.Header().Set(key, header)
-				} else {
-					c.Writer.Header().Add(key, header)
-				}
-			}
-		}
+		c.flushHeader()
 		fileServer.ServeHTTP(c.Writer, c.Request)
 	}
 	if strings.HasSuffix(relativePath, "/") {
@@ -216,6 +223,8 @@ func (e *Engine) TRACEAndName(path string, action Handler, routeName string) *En
 	return e.TRACE(path, action)
 }
 
+// Group creates a sub router sharing the prefix, middleware registered with Use
+// on the group only applies to its routes, nested groups compose both
 func (e *Engine) Group(prefix string, groupHandle ...func(e *Engine)) (engine *Engine) {
 	if prefix == "" {
 		return e
@@ -231,6 +240,8 @@ func (e *Engine) Group(prefix string, groupHandle ...func(e *Engine)) (engine *E
 		trees:      e.router.trees,
 		middleware: middleware,
 		notFound:   e.router.notFound,
+
+		methodNotAllowed: e.router.methodNotAllowed,
 	}
 	engine = &Engine{
 		router:              route,
@@ -315,8 +326,36 @@ func (e *Engine) PreHandler(preHandler Handler) {
 	e.preHandler = preHandler
 }
 
+// Before add a hook that runs for every request before routing, returning false stops the request
+func (e *Engine) Before(fn func(c *Context) bool) {
+	e.beforeHooks = append(e.beforeHooks, fn)
+}
+
+// After add a hook that runs for every request after the handlers, including 404 and aborted requests
+func (e *Engine) After(fn func(c *Context)) {
+	e.afterHooks = append(e.afterHooks, fn)
+}
+
 func (e *Engine) NotFoundHandler(handler Handler) {
-	e.router.notFound = Utils.ParseHandlerFunc(handler)
+	e.router.notFound = []handlerFn{Utils.ParseHandlerFunc(handler)}
+}
+
+// NotFound set the handlers for requests that match no route,
+// the global middleware runs before them
+func (e *Engine) NotFound(handlers ...Handler) {
+	e.router.notFound, _ = handlerFuncs(handlers)
+}
+
+// MethodNotAllowed respond 405 to requests whose path is registered under other methods,
+// the allowed methods are available through Context.AllowedMethods
+func (e *Engine) MethodNotAllowed(handlers ...Handler) {
+	e.router.methodNotAllowed, _ = handlerFuncs(handlers)
+	if len(e.router.methodNotAllowed) == 0 {
+		e.router.methodNotAllowed = []handlerFn{func(c *Context) error {
+			c.Engine.handleError(c, ErrMethodNotAllowed, http.StatusMethodNotAllowed)
+			return nil
+		}}
+	}
 }
 
 // Deprecated: please use znet.Recovery(func(c *Context, err error) {})
@@ -403,6 +442,9 @@ func (e *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	c := e.acquireContext()
 	c.clone(w, req)
 	defer func() {
+		for _, fn := range e.afterHooks {
+			fn(c)
+		}
 		c.write()
 		e.releaseContext(c)
 	}()
@@ -417,6 +459,11 @@ func (e *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			req.Method = strings.ToUpper(tmpType)
 		}
 	}
+	for _, fn := range e.beforeHooks {
+		if !fn(c) {
+			return
+		}
+	}
 	if e.preHandler != nil {
 		if preHandler, ok := e.preHandler.(func(*Context) bool); ok {
 			if preHandler(c) {
@@ -435,14 +482,89 @@ func (e *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if _, ok := e.router.trees[req.Method]; !ok {
-		e.HandleNotFound(c)
-		return
+	if _, ok := e.router.trees[req.Method]; !ok || e.FindHandle(c, req, p, true) {
+		if !e.handleAutoHead(c, p) && !e.handleAutoOptions(c, p) && !e.handleMethodNotAllowed(c, p) {
+			e.HandleNotFound(c)
+		}
 	}
+}
 
-	if e.FindHandle(c, req, p, true) {
-		e.HandleNotFound(c)
+// handleAutoHead run the GET route for HEAD requests without a HEAD route, the body is discarded on write
+func (e *Engine) handleAutoHead(c *Context, p string) bool {
+	if c.Request.Method != http.MethodHead {
+		return false
+	}
+	t, ok := e.router.trees[http.MethodGet]
+	if !ok {
+		return false
+	}
+	handler, middleware, route, ok := Utils.treeFind(t, p)
+	if !ok {
+		return false
 	}
+	c.route = route
+	handleAction(c, handler, middleware)
+	return true
+}
+
+// AutoOptions answer OPTIONS requests for registered paths that have no OPTIONS route,
+// the Allow header lists the methods registered for the path
+func (e *Engine) AutoOptions(allowHeaders ...string) {
+	e.autoOptions = true
+	e.autoOptionsHeaders = strings.Join(allowHeaders, ", ")
+}
+
+func (e *Engine) handleAutoOptions(c *Context, p string) bool {
+	if !e.autoOptions || c.Request.Method != http.MethodOptions {
+		return false
+	}
+
+	allow := e.allowedMethods(p)
+	if len(allow) == 0 {
+		return false
+	}
+	allow = append(allow, http.MethodOptions)
+
+	handleAction(c, func(c *Context) error {
+		methods := strings.Join(allow, ", ")
+		c.SetHeader("Allow", methods)
+		c.SetHeader("Access-Control-Allow-Methods", methods)
+		if e.autoOptionsHeaders != "" {
+			c.SetHeader("Access-Control-Allow-Headers", e.autoOptionsHeaders)
+		}
+		c.Byte(http.StatusNoContent, []byte{})
+		return nil
+	}, e.router.middleware)
+	return true
+}
+
+func (e *Engine) handleMethodNotAllowed(c *Context, p string) bool {
+	if len(e.router.methodNotAllowed) == 0 {
+		return false
+	}
+
+	allow := e.allowedMethods(p)
+	if len(allow) == 0 {
+		return false
+	}
+
+	c.WithValue(allowedMethodsKey, allow)
+	c.SetHeader("Allow", strings.Join(allow, ", "))
+	c.prevData.Code.Store(http.StatusMethodNotAllowed)
+	handleActions(c, e.router.methodNotAllowed, e.router.middleware)
+	return true
+}
+
+func (e *Engine) allowedMethods(p string) []string {
+	allow := make([]string, 0, len(autoOptionsMethods)+1)
+	for _, method := range autoOptionsMethods {
+		if t, ok := e.router.trees[method]; ok {
+			if _, _, ok = Utils.TreeFind(t, p); ok {
+				allow = append(allow, method)
+			}
+		}
+	}
+	return allow
 }
 
 func (e *Engine) FindHandle(rw *Context, req *http.Request, requestURL string, applyMiddleware bool) (not bool) {
@@ -451,10 +573,11 @@ func (e *Engine) FindHandle(rw *Context, req *http.Request, requestURL string, a
 		return true
 	}
 
-	handler, middleware, ok := Utils.TreeFind(t, requestURL)
+	handler, middleware, route, ok := Utils.treeFind(t, requestURL)
 	if !ok {
 		return true
 	}
+	rw.route = route
 
 	if applyMiddleware {
 		handleAction(rw, handler, middleware)
@@ -472,16 +595,48 @@ func (e *Engine) Use(middleware ...Handler) {
 	}
 }
 
+// UseFor same as Use, but the middleware only runs for requests using one of the methods
+func (e *Engine) UseFor(methods []string, middleware ...Handler) {
+	if len(middleware) == 0 {
+		return
+	}
+
+	allow := make(map[string]struct{}, len(methods))
+	for i := range methods {
+		allow[strings.ToUpper(methods[i])] = struct{}{}
+	}
+	only := func(fns []handlerFn) []handlerFn {
+		for i := range fns {
+			fn := fns[i]
+			fns[i] = func(c *Context) error {
+				if _, ok := allow[c.Request.Method]; !ok {
+					return nil
+				}
+				return fn(c)
+			}
+		}
+		return fns
+	}
+
+	fns, firstFns := handlerFuncs(middleware)
+	e.router.middleware = append(only(firstFns), e.router.middleware...)
+	e.router.middleware = append(e.router.middleware, only(fns)...)
+}
+
 func (e *Engine) HandleNotFound(c *Context) {
 	middleware := e.router.middleware
 	c.prevData.Code.Store(http.StatusNotFound)
 
-	if e.router.notFound != nil {
-		handleAction(c, e.router.notFound, middleware)
+	if len(e.router.notFound) > 0 {
+		handleActions(c, e.router.notFound, middleware)
 		return
 	}
 
 	handleAction(c, func(_ *Context) error {
+		if e.onError != nil {
+			e.onError(c, ErrNotFoundRoute, http.StatusNotFound)
+			return nil
+		}
 		c.Byte(404, []byte("404 page not found"))
 		return nil
 	}, middleware)
@@ -492,6 +647,12 @@ func handleAction(c *Context, handler handlerFn, middleware []handlerFn) {
 	c.Next()
 }
 
+func handleActions(c *Context, handlers []handlerFn, middleware []handlerFn) {
+	c.middleware = make([]handlerFn, 0, len(middleware)+len(handlers))
+	c.middleware = append(append(c.middleware, middleware...), handlers...)
+	c.Next()
+}
+
 // Match checks if the request matches the route pattern
 func (e *Engine) Match(requestURL string, path string) bool {
 	_, ok := Utils.URLMatchAndParse(requestURL, path)
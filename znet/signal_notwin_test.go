@@ -0,0 +1,102 @@
+//go:build !windows
+// +build !windows
+
+package znet
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestListenAndShutdown(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	tt.NoError(err)
+	addr := l.Addr().String()
+	_ = l.Close()
+
+	started := make(chan struct{})
+	r := New("TestListenAndShutdown")
+	r.SetMode(QuietMode)
+	r.GET("/ping", func(c *Context) {
+		c.String(200, "pong")
+	})
+	r.GET("/slow", func(c *Context) {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+		c.String(200, "done")
+	})
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	sig := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- r.listenAndShutdown(addr, sig)
+	}()
+	for i := 0; i < 100; i++ {
+		if res, err := client.Get("http://" + addr + "/ping"); err == nil {
+			_ = res.Body.Close()
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	body := make(chan string, 1)
+	go func() {
+		res, err := client.Get("http://" + addr + "/slow")
+		if err != nil {
+			body <- err.Error()
+			return
+		}
+		b, _ := ioutil.ReadAll(res.Body)
+		_ = res.Body.Close()
+		body <- string(b)
+	}()
+	<-started
+	sig <- syscall.SIGTERM
+
+	select {
+	case err := <-done:
+		tt.NoError(err)
+	case <-time.After(15 * time.Second):
+		t.Fatal("shutdown timeout")
+	}
+	tt.Equal("done", <-body)
+
+	_, err = client.Get("http://" + addr + "/ping")
+	tt.EqualTrue(err != nil)
+	tt.NoError(r.Shutdown())
+}
+
+func TestListenAndShutdownListenError(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	tt.NoError(err)
+	defer l.Close()
+
+	r := New("TestListenAndShutdownListenError")
+	r.SetMode(QuietMode)
+	done := make(chan error, 1)
+	go func() {
+		done <- r.ListenAndShutdown(l.Addr().String())
+	}()
+
+	select {
+	case err := <-done:
+		tt.EqualTrue(err != nil)
+	case <-time.After(5 * time.Second):
+		t.Fatal("listen error not returned")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	tt.NoError(r.Shutdown(ctx))
+}
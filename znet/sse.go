@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sohaha/zlsgo/zstring"
@@ -191,6 +192,7 @@ func NewSSE(c *Context, opts ...func(lastID string, opts *SSEOption)) *SSE {
 	s.net.SetHeader("Content-Type", "text/event-stream")
 	s.net.SetHeader("Cache-Control", "no-cache")
 	s.net.SetHeader("Connection", "keep-alive")
+	s.net.SetHeader("X-Accel-Buffering", "no")
 	c.prevData.Code.Store(http.StatusNoContent)
 	s.net.Engine.shutdowns = append(s.net.Engine.shutdowns, func() {
 		s.Stop()
@@ -213,3 +215,66 @@ func (c *Context) Stream(step func(w io.Writer) bool) bool {
 		}
 	}
 }
+
+// SSEWriter writes server-sent events directly to a response, flushing after each event
+type SSEWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+const sseWriterKey = "__zlsgo_sse_writer__"
+
+// NewSSEWriter set the event stream headers on w and wrap it
+func NewSSEWriter(w http.ResponseWriter) *SSEWriter {
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	flusher, _ := w.(http.Flusher)
+	return &SSEWriter{w: w, flusher: flusher}
+}
+
+// Send write one event, empty id and event are omitted and multi-line data is split into data lines
+func (s *SSEWriter) Send(id, event, data string) error {
+	b := zstring.Buffer(4)
+	if id != "" {
+		b.WriteString("id: ")
+		b.WriteString(id)
+		b.WriteString("\n")
+	}
+	if event != "" {
+		b.WriteString("event: ")
+		b.WriteString(event)
+		b.WriteString("\n")
+	}
+	for _, v := range strings.Split(data, "\n") {
+		b.WriteString("data: ")
+		b.WriteString(v)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if _, err := io.WriteString(s.w, b.String()); err != nil {
+		return err
+	}
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+	return nil
+}
+
+// SSEvent push a server-sent event to the client, the response headers are sent on the first call
+// and anything rendered before or after the events is dropped
+func (c *Context) SSEvent(event, data string) error {
+	w, ok := c.Value(sseWriterKey)
+	if !ok {
+		c.flushHeader()
+		w = NewSSEWriter(c.Writer)
+		c.streamed = true
+		c.prevData.Code.Store(http.StatusOK)
+		c.Writer.WriteHeader(http.StatusOK)
+		c.WithValue(sseWriterKey, w)
+	}
+	return w.(*SSEWriter).Send("", event, data)
+}
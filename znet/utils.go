@@ -195,12 +195,18 @@ func getHostname(addr string, isTls bool) string {
 }
 
 func (u utils) TreeFind(t *Tree, path string) (handlerFn, []handlerFn, bool) {
+	handler, middleware, _, ok := u.treeFind(t, path)
+	return handler, middleware, ok
+}
+
+// treeFind same as TreeFind, but also returns the pattern of the matched route
+func (u utils) treeFind(t *Tree, path string) (handlerFn, []handlerFn, string, bool) {
 	nodes := t.Find(path, false)
 	for i := range nodes {
 		node := nodes[i]
 		if node.handle != nil {
 			if node.path == path {
-				return node.handle, node.middleware, true
+				return node.handle, node.middleware, node.path, true
 			}
 		}
 	}
@@ -222,12 +228,12 @@ func (u utils) TreeFind(t *Tree, path string) (handlerFn, []handlerFn, bool) {
 						ctx := context.WithValue(req.Context(), u.ContextKey, matchParamsMap)
 						c.Request = req.WithContext(ctx)
 						return node.Handle()(c)
-					}, node.middleware, true
+					}, node.middleware, node.path, true
 				}
 			}
 		}
 	}
-	return nil, nil, false
+	return nil, nil, "", false
 }
 
 func (_ utils) CompletionPath(p, prefix string) string {
@@ -339,6 +345,9 @@ func (c *Context) clone(w http.ResponseWriter, r *http.Request) {
 	c.injector.Maps(c)
 	c.startTime = time.Now()
 	c.renderError = defErrorHandler()
+	c.route = ""
+	c.renderFailing = false
+	c.streamed = false
 	c.stopHandle.Store(false)
 	c.done.Store(false)
 }
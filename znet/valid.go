@@ -1,6 +1,14 @@
 package znet
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/sohaha/zlsgo/zreflect"
+	"github.com/sohaha/zlsgo/ztype"
 	"github.com/sohaha/zlsgo/zvalid"
 )
 
@@ -46,3 +54,186 @@ func (c *Context) Valid(defRule zvalid.Engine, key string, name ...string) zvali
 func valid(defRule zvalid.Engine, value, key string, name ...string) (valid zvalid.Engine) {
 	return defRule.Verifi(value, name...)
 }
+
+// FieldError a field that failed validation
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError lists every field that failed BindAndValidate
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msg := make([]string, len(e.Fields))
+	for i := range e.Fields {
+		msg[i] = e.Fields[i].Message
+	}
+	return strings.Join(msg, "; ")
+}
+
+// BindAndValidate bind the request then check the fields against their validate tag,
+// e.g. validate:"required,min=1,max=100", failures are returned as *ValidationError.
+// Nested and embedded structs without a validate tag are checked recursively,
+// unexported fields are skipped and required rejects zero values such as 0 or false
+func (c *Context) BindAndValidate(obj interface{}) error {
+	if err := c.Bind(obj); err != nil {
+		return err
+	}
+	return validateStruct(obj)
+}
+
+func validateStruct(obj interface{}) error {
+	val := reflect.Indirect(zreflect.ValueOf(obj))
+	if val.Kind() != reflect.Struct {
+		return errors.New("result must be a pointer to struct")
+	}
+
+	var fields []FieldError
+	if err := validateFields(val, "", &fields); err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+func validateFields(val reflect.Value, prefix string, fields *[]FieldError) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, _ := zreflect.GetStructTag(field)
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		fv := val.Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() != reflect.Struct {
+				continue
+			}
+			if field.Anonymous {
+				name = prefix
+			}
+			if err := validateFields(fv, name, fields); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		kind := fv.Kind()
+		rule, err := validateRule(kind, tag)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+
+		var value string
+		switch kind {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			if fv.Len() > 0 {
+				value = strconv.Itoa(fv.Len())
+			}
+		default:
+			if !fv.IsZero() || !hasValidateRule(tag, "required") {
+				value = ztype.ToString(fv.Interface())
+			}
+		}
+		if err = rule.Verifi(value, name).Error(); err != nil {
+			*fields = append(*fields, FieldError{Field: name, Message: err.Error()})
+		}
+	}
+	return nil
+}
+
+func hasValidateRule(tag, name string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if strings.TrimSpace(r) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRule(kind reflect.Kind, tag string) (zvalid.Engine, error) {
+	rule := zvalid.New()
+	for _, r := range strings.Split(tag, ",") {
+		r = strings.TrimSpace(r)
+		arg := ""
+		if i := strings.IndexByte(r, '='); i >= 0 {
+			r, arg = r[:i], r[i+1:]
+		}
+
+		switch r {
+		case "":
+		case "required":
+			rule = rule.Required()
+		case "min", "max":
+			n, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return rule, fmt.Errorf("invalid %s rule: %s", r, arg)
+			}
+			rule = validateRange(rule, kind, r == "min", n)
+		case "oneof":
+			rule = rule.EnumString(strings.Fields(arg))
+		case "email":
+			rule = rule.IsMail()
+		case "url":
+			rule = rule.IsURL()
+		case "ip":
+			rule = rule.IsIP()
+		case "mobile":
+			rule = rule.IsMobile()
+		case "number":
+			rule = rule.IsNumber()
+		case "integer":
+			rule = rule.IsInteger()
+		case "letter":
+			rule = rule.IsLetter()
+		case "lower":
+			rule = rule.IsLower()
+		case "upper":
+			rule = rule.IsUpper()
+		case "json":
+			rule = rule.IsJSON()
+		default:
+			return rule, errors.New("unknown validate rule: " + r)
+		}
+	}
+	return rule, nil
+}
+
+func validateRange(rule zvalid.Engine, kind reflect.Kind, min bool, n float64) zvalid.Engine {
+	switch kind {
+	case reflect.String:
+		if min {
+			return rule.MinUTF8Length(int(n))
+		}
+		return rule.MaxUTF8Length(int(n))
+	case reflect.Float32, reflect.Float64:
+		if min {
+			return rule.MinFloat(n)
+		}
+		return rule.MaxFloat(n)
+	default:
+		if min {
+			return rule.MinInt(int(n))
+		}
+		return rule.MaxInt(int(n))
+	}
+}
@@ -111,3 +111,79 @@ func TestContextBatchValid(tt *testing.T) {
 	})
 	t.Equal(200, w.Code)
 }
+
+func TestBindAndValidate(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestBindAndValidate")
+
+	type user struct {
+		Name  string   `json:"name" validate:"required,min=2,max=5"`
+		Email string   `json:"email" validate:"email"`
+		Role  string   `json:"role" validate:"oneof=admin user"`
+		Tags  []string `json:"tags" validate:"required,max=2"`
+		Age   int      `json:"age" validate:"min=1,max=100"`
+		Note  string   `json:"note"`
+	}
+	var err error
+	r.POST("/", func(c *Context) {
+		var s user
+		err = c.BindAndValidate(&s)
+	})
+
+	_ = newRequest(r, "POST", []string{"/", `{"name":"zls","email":"a@b.cn","role":"admin","tags":["a"],"age":18}`, mimeJSON}, "")
+	tt.NoError(err)
+
+	_ = newRequest(r, "POST", []string{"/", `{"name":"z","email":"nope","role":"root","tags":["a","b","c"],"age":101}`, mimeJSON}, "")
+	verr, ok := err.(*ValidationError)
+	tt.EqualTrue(ok)
+	tt.Equal(5, len(verr.Fields))
+	tt.Equal([]string{"name", "email", "role", "tags", "age"}, []string{verr.Fields[0].Field, verr.Fields[1].Field, verr.Fields[2].Field, verr.Fields[3].Field, verr.Fields[4].Field})
+	tt.EqualTrue(verr.Error() != "")
+
+	_ = newRequest(r, "POST", []string{"/", `{"age":0}`, mimeJSON}, "")
+	verr, ok = err.(*ValidationError)
+	tt.EqualTrue(ok)
+	tt.Equal([]string{"name", "tags", "age"}, []string{verr.Fields[0].Field, verr.Fields[1].Field, verr.Fields[2].Field})
+
+	type (
+		Base struct {
+			ID int `json:"id" validate:"required"`
+		}
+		address struct {
+			City string `json:"city" validate:"required"`
+		}
+		order struct {
+			Base
+			Address address  `json:"address"`
+			Ptr     *address `json:"ptr"`
+			Paid    bool     `json:"paid" validate:"required"`
+			secret  string   `validate:"required"`
+		}
+	)
+	r.POST("/nested", func(c *Context) {
+		s := order{Base: Base{ID: 1}}
+		err = c.BindAndValidate(&s)
+	})
+	_ = newRequest(r, "POST", []string{"/nested", `{"address":{"city":"sz"},"paid":true}`, mimeJSON}, "")
+	tt.NoError(err)
+	_ = newRequest(r, "POST", []string{"/nested", `{"address":{},"paid":false}`, mimeJSON}, "")
+	verr, ok = err.(*ValidationError)
+	tt.EqualTrue(ok)
+	tt.Equal(2, len(verr.Fields))
+	tt.Equal([]string{"address.city", "paid"}, []string{verr.Fields[0].Field, verr.Fields[1].Field})
+
+	tt.NoError(validateStruct(&order{Base: Base{ID: 1}, Address: address{City: "a"}, Ptr: &address{City: "b"}, Paid: true}))
+	verr, ok = validateStruct(&order{Address: address{City: "a"}, Ptr: &address{}, Paid: true}).(*ValidationError)
+	tt.EqualTrue(ok)
+	tt.Equal([]string{"id", "ptr.city"}, []string{verr.Fields[0].Field, verr.Fields[1].Field})
+
+	r.POST("/bad", func(c *Context) {
+		var s struct {
+			Name string `json:"name" validate:"unknown"`
+		}
+		err = c.BindAndValidate(&s)
+	})
+	_ = newRequest(r, "POST", []string{"/bad", `{"name":"zls"}`, mimeJSON}, "")
+	_, ok = err.(*ValidationError)
+	tt.EqualTrue(err != nil && !ok)
+}
@@ -9,8 +9,10 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/sohaha/zlsgo/zdi"
@@ -43,12 +45,15 @@ type (
 		Engine        *Engine
 		Log           *zlog.Logger
 		// Deprecated: Please maintain your own cache
-		Cache       *zcache.Table
-		renderError ErrHandlerFunc
-		cacheQuery  url.Values
-		rawData     []byte
-		middleware  []handlerFn
-		mu          sync.RWMutex
+		Cache         *zcache.Table
+		renderError   ErrHandlerFunc
+		cacheQuery    url.Values
+		route         string
+		renderFailing bool
+		streamed      bool
+		rawData       []byte
+		middleware    []handlerFn
+		mu            sync.RWMutex
 	}
 	// Engine is a simple HTTP route multiplexer that parses a request path
 	Engine struct {
@@ -56,6 +61,12 @@ type (
 		injector             zdi.Injector
 		preHandler           Handler
 		views                Template
+		jsonMarshaler        func(v interface{}) ([]byte, error)
+		flashSecret          []byte
+		onError              func(c *Context, err error, code int)
+		beforeHooks          []func(c *Context) bool
+		afterHooks           []func(c *Context)
+		autoOptionsHeaders   string
 		Cache                *zcache.Table
 		template             *tpl
 		Log                  *zlog.Logger
@@ -69,12 +80,16 @@ type (
 		customMethodType     string
 		addr                 []addrSt
 		shutdowns            []func()
+		servers              []*http.Server
+		listenErr            chan error
 		MaxMultipartMemory   int64
 		webMode              int
 		writeTimeout         time.Duration
 		readTimeout          time.Duration
 		ShowFavicon          bool
 		AllowQuerySemicolons bool
+		autoOptions          bool
+		serverMu             sync.Mutex
 	}
 	TlsCfg struct {
 		HTTPProcessing interface{}
@@ -93,11 +108,12 @@ type (
 		addr string
 	}
 	router struct {
-		trees      map[string]*Tree
-		notFound   handlerFn
-		prefix     string
-		parameters Parameters
-		middleware []handlerFn
+		trees            map[string]*Tree
+		notFound         []handlerFn
+		methodNotAllowed []handlerFn
+		prefix           string
+		parameters       Parameters
+		middleware       []handlerFn
 	}
 	// Handler handler func
 	Handler      interface{}
@@ -210,6 +226,38 @@ func WrapFirstMiddleware(fn Handler) firstHandler {
 	return firstHandler{fn}
 }
 
+// WrapHandler adapt a standard http.Handler, it writes to the response directly
+func WrapHandler(h http.Handler) HandlerFunc {
+	return func(c *Context) {
+		c.flushHeader()
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// WrapMiddleware adapt a standard net/http middleware, the rest of the chain runs as its next handler,
+// the chain is aborted if the middleware does not call next
+func WrapMiddleware(m func(http.Handler) http.Handler) HandlerFunc {
+	return func(c *Context) {
+		called := false
+		writer := c.Writer
+		m(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			c.Request = r
+			if w == writer {
+				c.Next()
+				return
+			}
+			c.Writer = w
+			c.Next()
+			c.write()
+			c.Writer = writer
+		})).ServeHTTP(writer, c.Request)
+		if !called {
+			c.Abort()
+		}
+	}
+}
+
 // Server Server
 func Server(serverName ...string) (engine *Engine, ok bool) {
 	name := defaultServerName
@@ -365,6 +413,29 @@ func (e *Engine) SetTimeout(Timeout time.Duration, WriteTimeout ...time.Duration
 	}
 }
 
+// SetJSONMarshaler set custom JSON marshaler for all JSON responses, nil restores the default
+func (e *Engine) SetJSONMarshaler(fn func(v interface{}) ([]byte, error)) {
+	e.jsonMarshaler = fn
+}
+
+// OnError set a hook for framework generated error responses,
+// such as handler errors, bind failures, default 404/405 and Recovery(nil)
+func (e *Engine) OnError(fn func(c *Context, err error, code int)) {
+	e.onError = fn
+}
+
+func (e *Engine) handleError(c *Context, err error, code int) {
+	if c.streamed {
+		c.Log.Error(err)
+		return
+	}
+	if e.onError != nil {
+		e.onError(c, err, code)
+		return
+	}
+	c.String(int32(code), err.Error())
+}
+
 func (e *Engine) StartUp() []*serverMap {
 	var wg sync.WaitGroup
 	var srvMap sync.Map
@@ -389,6 +460,7 @@ func (e *Engine) StartUp() []*serverMap {
 			}
 
 			srvMap.Store(addr, &serverMap{e, srv})
+			listenErr := e.listenErr
 
 			wg.Done()
 
@@ -433,7 +505,13 @@ func (e *Engine) StartUp() []*serverMap {
 
 			err := <-errChan
 			if err != nil && err != http.ErrServerClosed {
-				e.Log.Fatalf("Listen: %s\n", err)
+				if listenErr == nil {
+					e.Log.Fatalf("Listen: %s\n", err)
+				}
+				select {
+				case listenErr <- err:
+				default:
+				}
 			} else if err != http.ErrServerClosed {
 				e.Log.Info(err)
 			}
@@ -444,13 +522,81 @@ func (e *Engine) StartUp() []*serverMap {
 	wg.Wait()
 
 	srvs := make([]*serverMap, 0)
+	e.serverMu.Lock()
 	srvMap.Range(func(addr, value interface{}) bool {
-		srvs = append(srvs, value.(*serverMap))
+		s := value.(*serverMap)
+		srvs = append(srvs, s)
+		e.servers = append(e.servers, s.srv)
 		return true
 	})
+	e.serverMu.Unlock()
 	return srvs
 }
 
+// Shutdown gracefully stop the servers started by this engine, in-flight requests
+// get until ctx is done to finish, without ctx the limit is 20 seconds
+func (e *Engine) Shutdown(ctx ...context.Context) error {
+	e.serverMu.Lock()
+	servers := e.servers
+	e.servers = nil
+	e.serverMu.Unlock()
+	if len(servers) == 0 {
+		return nil
+	}
+
+	for _, shutdown := range e.shutdowns {
+		shutdown()
+	}
+
+	c := context.Background()
+	if len(ctx) > 0 && ctx[0] != nil {
+		c = ctx[0]
+	} else {
+		var cancel context.CancelFunc
+		c, cancel = context.WithTimeout(c, 20*time.Second)
+		defer cancel()
+	}
+	var err error
+	for _, srv := range servers {
+		if sErr := srv.Shutdown(c); sErr != nil {
+			_ = srv.Close()
+			if err == nil {
+				err = sErr
+			}
+		}
+	}
+	return err
+}
+
+// ListenAndShutdown serve on addr until one of the signals is received, then Shutdown,
+// defaults to SIGINT and SIGTERM, a listen failure is returned instead of exiting
+func (e *Engine) ListenAndShutdown(addr string, signals ...os.Signal) error {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, signals...)
+	defer signal.Stop(sig)
+
+	return e.listenAndShutdown(addr, sig)
+}
+
+func (e *Engine) listenAndShutdown(addr string, sig <-chan os.Signal) error {
+	listenErr := make(chan error, 1)
+	e.listenErr = listenErr
+	e.SetAddr(addr)
+	e.StartUp()
+	e.listenErr = nil
+
+	select {
+	case <-sig:
+		return e.Shutdown()
+	case err := <-listenErr:
+		_ = e.Shutdown()
+		return err
+	}
+}
+
 func Shutdown() {
 	shutdown(true)
 }
@@ -1,11 +1,17 @@
 package znet
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
 	"errors"
 	"fmt"
 	"html/template"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -230,6 +236,52 @@ func TestGroup(t *testing.T) {
 	tt.Equal("isGroup3", w.Body.String())
 }
 
+func TestGroupMiddleware(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestGroupMiddleware")
+	r.SetMode(TestMode)
+
+	mark := func(name string) func(c *Context) {
+		return func(c *Context) {
+			c.WithValue("mark", c.MustValue("mark", "").(string)+name)
+			c.Next()
+		}
+	}
+	h := func(c *Context) {
+		c.String(200, c.MustValue("mark", "").(string))
+	}
+
+	r.GET("/outside", h)
+	r.Group("/api", func(g *Engine) {
+		g.Use(mark("api"))
+		g.GET("/ping", h)
+		g.Group("/v1", func(g *Engine) {
+			g.Use(mark("-v1"))
+			g.GET("/ping", h)
+		})
+		g.GET("/after", h)
+	})
+
+	w := request(r, "GET", "/outside", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal("", w.Body.String())
+
+	w = request(r, "GET", "/api/ping", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal("api", w.Body.String())
+
+	w = request(r, "GET", "/api/v1/ping", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal("api-v1", w.Body.String())
+
+	w = request(r, "GET", "/api/after", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal("api", w.Body.String())
+
+	w = request(r, "GET", "/v1/ping", nil)
+	tt.Equal(404, w.Code)
+}
+
 func TestRedirect(t *testing.T) {
 	tt := zlsgo.NewTest(t)
 	r := newServer()
@@ -828,6 +880,137 @@ func TestGetInput(T *testing.T) {
 	t.Equal(expected, w.Body.String())
 }
 
+func TestQuery(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestQuery")
+	r.GET("/", func(c *Context) {
+		q := c.Query()
+		tt.Equal(10, q.GetInt("page", 1))
+		tt.Equal(20, q.GetInt("size", 20))
+		tt.Equal(1, q.GetInt("offset", 1))
+		tt.Equal(1, q.GetInt("limit", 1))
+		tt.Equal(0.1, q.GetFloat("offset", 0.1))
+		tt.Equal(0.5, q.GetFloat("rate", 0))
+		tt.EqualTrue(q.GetBool("debug", false))
+		tt.EqualTrue(!q.GetBool("none", false))
+		tt.EqualTrue(!q.GetBool("verbose", true))
+		tt.EqualTrue(q.GetBool("trace", true))
+		tt.Equal("zls", q.GetString("name", ""))
+		tt.Equal("def", q.GetString("none", "def"))
+		tt.Equal([]string{"1", "2", "3"}, q.GetSlice("ids", nil))
+		tt.Equal([]string{"x"}, q.GetSlice("none", []string{"x"}))
+		c.String(200, "ok")
+	})
+
+	w := request(r, "GET", "/?page=10&offset=abc&limit=&rate=0.5&debug=true&verbose=no&trace=x&name=zls&ids=1,2&ids=3", nil)
+	tt.Equal("ok", w.Body.String())
+}
+
+func TestForm(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestForm")
+	r.POST("/", func(c *Context) {
+		f := c.Form()
+		tt.Equal(18, f.GetInt("age", 0))
+		tt.Equal(-1, f.GetInt("none", -1))
+		tt.Equal(1.5, f.GetFloat("price", 0))
+		tt.Equal(2.5, f.GetFloat("none", 2.5))
+		tt.Equal(-1, f.GetInt("bad", -1))
+		tt.Equal(-1, f.GetInt("empty", -1))
+		tt.Equal(2.5, f.GetFloat("bad", 2.5))
+		tt.Equal(2.5, f.GetFloat("empty", 2.5))
+		tt.EqualTrue(f.GetBool("a", false))
+		tt.EqualTrue(f.GetBool("b", false))
+		tt.EqualTrue(f.GetBool("c", false))
+		tt.EqualTrue(!f.GetBool("d", true))
+		tt.EqualTrue(f.GetBool("none", true))
+		tt.EqualTrue(f.GetBool("e", false))
+		tt.EqualTrue(!f.GetBool("f", true))
+		tt.EqualTrue(!f.GetBool("g", true))
+		tt.EqualTrue(!f.GetBool("h", true))
+		tt.EqualTrue(!f.GetBool("i", true))
+		tt.EqualTrue(f.GetBool("j", true))
+		tt.EqualTrue(!f.GetBool("j", false))
+		tt.Equal("zls", f.GetString("name", ""))
+		tt.Equal("def", f.GetString("none", "def"))
+		c.String(200, "ok")
+	})
+
+	w := request(r, "POST", "/", strings.NewReader("age=18&price=1.5&bad=abc&empty=&a=true&b=1&c=yes&d=false&e=ON&f=no&g=off&h=False&i=FALSE&j=maybe&name=zls"), func(w *httptest.ResponseRecorder, req *http.Request) {
+		req.Header.Set("Content-Type", mimePOSTForm)
+	})
+	tt.Equal("ok", w.Body.String())
+}
+
+func TestSaveUploadedFile(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	dir := t.TempDir()
+	dst := filepath.Join(dir, "a", "b", "upload.txt")
+	r := New("TestSaveUploadedFile")
+	r.POST("/", func(c *Context) error {
+		f, err := c.FormFile("file")
+		if err != nil {
+			return err
+		}
+		return c.SaveUploadedFile(f, dst)
+	})
+
+	upload := func(content string) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+		fw, _ := mw.CreateFormFile("file", "upload.txt")
+		_, _ = fw.Write([]byte(content))
+		_ = mw.Close()
+		return request(r, "POST", "/", body, func(w *httptest.ResponseRecorder, req *http.Request) {
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+		})
+	}
+
+	w := upload("hello zlsgo")
+	tt.Equal(200, w.Code)
+	b, err := ioutil.ReadFile(dst)
+	tt.NoError(err)
+	tt.Equal("hello zlsgo", string(b))
+
+	w = upload("new")
+	tt.Equal(200, w.Code)
+	b, _ = ioutil.ReadFile(dst)
+	tt.Equal("new", string(b))
+
+	c := &Context{}
+	tt.EqualTrue(c.SaveUploadedFile(&multipart.FileHeader{Filename: "none"}, filepath.Join(dir, "none.txt")) != nil)
+}
+
+func TestGetQueryArrayExpanded(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestGetQueryArrayExpanded")
+	r.GET("/", func(c *Context) {
+		v, ok := c.GetQueryArrayExpanded("ids")
+		if !ok {
+			c.String(404, "")
+			return
+		}
+		c.String(200, strings.Join(v, "|"))
+	})
+
+	for query, expected := range map[string]string{
+		"ids=1&ids=2":         "1|2",
+		"ids=1,2":             "1|2",
+		"ids=1,2&ids=3":       "1|2|3",
+		"ids=1":               "1",
+		"ids=1,,2&ids=&ids=3": "1|2|3",
+	} {
+		w := request(r, "GET", "/?"+query, nil)
+		tt.Equal(200, w.Code)
+		tt.Equal(expected, w.Body.String())
+	}
+
+	w := request(r, "GET", "/?ids=,", nil)
+	tt.Equal(404, w.Code)
+	w = request(r, "GET", "/", nil)
+	tt.Equal(404, w.Code)
+}
+
 func TestRecovery(t *testing.T) {
 	tt := zlsgo.NewTest(t)
 	r := New("TestRecovery")
@@ -887,3 +1070,625 @@ func TestMethodAndName(t *testing.T) {
 
 	t.Log(r.GenerateURL(http.MethodPost, "non existent", nil))
 }
+
+func TestStreamJSON(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestStreamJSON")
+	r.GET("/", func(c *Context) error {
+		c.SetHeader("X-Stream", "1")
+		return c.StreamJSON(map[string]interface{}{"name": "zls", "ids": []int{1, 2}})
+	})
+	r.GET("/fail", func(c *Context) error {
+		return c.StreamJSON(make(chan int))
+	})
+	var partialErr error
+	r.GET("/partial", func(c *Context) error {
+		c.Writer = &shortWriter{ResponseWriter: c.Writer, limit: 8}
+		partialErr = c.StreamJSON(map[string]interface{}{"name": strings.Repeat("z", 64)})
+		return partialErr
+	})
+	r.GET("/status", func(c *Context) error {
+		c.SetStatus(201)
+		return c.StreamJSON([]int{1})
+	})
+	var hooked int
+	r.OnError(func(c *Context, err error, code int) {
+		hooked++
+		c.String(int32(code), err.Error())
+	})
+
+	w := request(r, "GET", "/", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal(`{"ids":[1,2],"name":"zls"}`+"\n", w.Body.String())
+	tt.Equal(ContentTypeJSON, w.Header().Get("Content-Type"))
+	tt.Equal("1", w.Header().Get("X-Stream"))
+
+	w = request(r, "GET", "/fail", nil)
+	tt.Equal(500, w.Code)
+	tt.Equal(1, hooked)
+
+	w = request(r, "GET", "/partial", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal(`{"name":`, w.Body.String())
+	tt.Equal(io.ErrShortWrite, partialErr)
+	tt.Equal(1, hooked)
+
+	w = request(r, "GET", "/status", nil)
+	tt.Equal(201, w.Code)
+	tt.Equal("[1]\n", w.Body.String())
+
+	marshaled := 0
+	r.SetJSONMarshaler(func(v interface{}) ([]byte, error) {
+		marshaled++
+		return []byte(`"custom"`), nil
+	})
+	w = request(r, "GET", "/", nil)
+	tt.Equal(`"custom"`, w.Body.String())
+	tt.Equal(1, marshaled)
+}
+
+func TestXML(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	type user struct {
+		XMLName struct{} `xml:"user"`
+		Name    string   `xml:"name"`
+		ID      int      `xml:"id,attr"`
+	}
+	r := New("TestXML")
+	r.GET("/", func(c *Context) {
+		c.XML(201, user{Name: "zls", ID: 1})
+	})
+	r.GET("/header", func(c *Context) {
+		c.XML(200, user{Name: "zls", ID: 1}, true)
+	})
+	r.GET("/fail", func(c *Context) {
+		c.XML(200, map[string]string{"name": "zls"})
+	})
+
+	w := request(r, "GET", "/", nil)
+	tt.Equal(201, w.Code)
+	tt.Equal(`<user id="1"><name>zls</name></user>`, w.Body.String())
+	tt.Equal(ContentTypeXML, w.Header().Get("Content-Type"))
+
+	w = request(r, "GET", "/header", nil)
+	tt.Equal(`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<user id="1"><name>zls</name></user>`, w.Body.String())
+
+	w = request(r, "GET", "/fail", nil)
+	tt.Equal(500, w.Code)
+	tt.Equal(ContentTypePlain, w.Header().Get("Content-Type"))
+	tt.Equal(http.StatusText(500), w.Body.String())
+
+	var code int
+	r.OnError(func(c *Context, err error, status int) {
+		code = status
+		c.String(int32(status), "xml failed")
+	})
+	w = request(r, "GET", "/fail", nil)
+	tt.Equal(500, code)
+	tt.Equal(500, w.Code)
+	tt.Equal("xml failed", w.Body.String())
+}
+
+func TestSetJSONMarshaler(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestSetJSONMarshaler")
+	r.GET("/", func(c *Context) {
+		c.JSON(200, map[string]string{"name": "zls"})
+	})
+	r.GET("/api", func(c *Context) {
+		c.ApiJSON(200, "ok", "zls")
+	})
+	r.GET("/fail", func(c *Context) {
+		c.JSON(200, func() {})
+	})
+
+	w := request(r, "GET", "/", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal(`{"name":"zls"}`, w.Body.String())
+
+	r.SetJSONMarshaler(func(v interface{}) ([]byte, error) {
+		return []byte(`"custom"`), nil
+	})
+	w = request(r, "GET", "/", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal(`"custom"`, w.Body.String())
+	tt.Equal(ContentTypeJSON, w.Header().Get("Content-Type"))
+	w = request(r, "GET", "/api", nil)
+	tt.Equal(`"custom"`, w.Body.String())
+
+	r.SetJSONMarshaler(func(v interface{}) ([]byte, error) {
+		return nil, errors.New("marshal error")
+	})
+	w = request(r, "GET", "/", nil)
+	tt.Equal(500, w.Code)
+	tt.Equal(http.StatusText(500), w.Body.String())
+
+	r.SetJSONMarshaler(nil)
+	w = request(r, "GET", "/", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal(`{"name":"zls"}`, w.Body.String())
+
+	w = request(r, "GET", "/fail", nil)
+	tt.Equal(500, w.Code)
+	tt.Equal(http.StatusText(500), w.Body.String())
+
+	var hookErr error
+	r.OnError(func(c *Context, err error, code int) {
+		hookErr = err
+		c.JSON(int32(code), map[string]interface{}{"code": code, "msg": "render failed"})
+	})
+	w = request(r, "GET", "/fail", nil)
+	tt.Equal(500, w.Code)
+	tt.EqualTrue(hookErr != nil)
+	tt.Equal(ContentTypeJSON, w.Header().Get("Content-Type"))
+	tt.Equal("render failed", zjson.Get(w.Body.String(), "msg").String())
+
+	r.OnError(func(c *Context, err error, code int) {
+		c.JSON(int32(code), func() {})
+	})
+	w = request(r, "GET", "/fail", nil)
+	tt.Equal(500, w.Code)
+	tt.Equal(http.StatusText(500), w.Body.String())
+}
+
+func TestAny(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestAny")
+	r.Any("/any", func(c *Context) {
+		c.String(200, c.Request.Method)
+	})
+
+	for _, method := range []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"} {
+		w := request(r, method, "/any", nil)
+		tt.Equal(200, w.Code)
+		tt.Equal(method, w.Body.String())
+	}
+
+	w := request(r, "HEAD", "/any", nil)
+	tt.Equal(200, w.Code)
+}
+
+func TestDecompressRequest(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestDecompressRequest")
+	r.Use(DecompressRequest(func(o *DecompressOption) {
+		o.MaxBytes = 16
+	}, WithDecoder("X-Upper", func(r io.Reader) (io.ReadCloser, error) {
+		b, err := ioutil.ReadAll(r)
+		return ioutil.NopCloser(bytes.NewReader(bytes.ToUpper(b))), err
+	})))
+	r.POST("/", func(c *Context) error {
+		body, err := c.GetDataRaw()
+		if err != nil {
+			return err
+		}
+		c.String(200, body)
+		return nil
+	})
+
+	encoding := func(v string) func(w *httptest.ResponseRecorder, req *http.Request) {
+		return func(w *httptest.ResponseRecorder, req *http.Request) {
+			req.Header.Set("Content-Encoding", v)
+		}
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, _ = gw.Write([]byte("gzip body"))
+	_ = gw.Close()
+	w := request(r, "POST", "/", bytes.NewReader(buf.Bytes()), encoding("gzip"))
+	tt.Equal(200, w.Code)
+	tt.Equal("gzip body", w.Body.String())
+
+	buf.Reset()
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write([]byte("deflate body"))
+	_ = zw.Close()
+	w = request(r, "POST", "/", bytes.NewReader(buf.Bytes()), encoding("deflate"))
+	tt.Equal(200, w.Code)
+	tt.Equal("deflate body", w.Body.String())
+
+	buf.Reset()
+	fw, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+	_, _ = fw.Write([]byte("raw deflate"))
+	_ = fw.Close()
+	w = request(r, "POST", "/", bytes.NewReader(buf.Bytes()), encoding("deflate"))
+	tt.Equal(200, w.Code)
+	tt.Equal("raw deflate", w.Body.String())
+
+	w = request(r, "POST", "/", strings.NewReader("custom"), encoding("x-upper"))
+	tt.Equal(200, w.Code)
+	tt.Equal("CUSTOM", w.Body.String())
+
+	w = request(r, "POST", "/", strings.NewReader("br body"), encoding("br"))
+	tt.Equal(415, w.Code)
+
+	buf.Reset()
+	gw = gzip.NewWriter(&buf)
+	_, _ = gw.Write(bytes.Repeat([]byte("z"), 1024))
+	_ = gw.Close()
+	w = request(r, "POST", "/", bytes.NewReader(buf.Bytes()), encoding("gzip"))
+	tt.Equal(413, w.Code)
+
+	buf.Reset()
+	gw = gzip.NewWriter(&buf)
+	_, _ = gw.Write(bytes.Repeat([]byte("z"), 16))
+	_ = gw.Close()
+	w = request(r, "POST", "/", bytes.NewReader(buf.Bytes()), encoding("gzip"))
+	tt.Equal(200, w.Code)
+	tt.Equal(16, w.Body.Len())
+
+	w = request(r, "POST", "/", strings.NewReader("sz body"), encoding("sz"))
+	tt.Equal(415, w.Code)
+
+	w = request(r, "POST", "/", strings.NewReader("not gzip"), encoding("gzip"))
+	tt.Equal(400, w.Code)
+
+	w = request(r, "POST", "/", strings.NewReader("plain body"))
+	tt.Equal(200, w.Code)
+	tt.Equal("plain body", w.Body.String())
+}
+
+func TestOnError(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestOnError")
+	r.Use(Recovery(nil))
+	r.OnError(func(c *Context, err error, code int) {
+		c.JSON(int32(code), map[string]interface{}{"code": code, "msg": err.Error()})
+	})
+	r.POST("/bind", func(c *Context) error {
+		var v struct {
+			Name string `json:"name"`
+		}
+		return c.BindJSON(&v)
+	})
+	r.POST("/should", func(c *Context) error {
+		var v struct {
+			Name string `json:"name"`
+		}
+		return c.ShouldBind(&v)
+	})
+	r.POST("/must", func(c *Context) {
+		var v struct {
+			Name string `json:"name"`
+		}
+		if c.MustBind(&v) != nil {
+			return
+		}
+		c.String(200, v.Name)
+	})
+	r.GET("/panic", func(c *Context) {
+		panic("boom")
+	})
+
+	w := request(r, "GET", "/none", nil)
+	tt.Equal(404, w.Code)
+	tt.Equal(404, zjson.Get(w.Body.String(), "code").Int())
+
+	w = request(r, "POST", "/bind", strings.NewReader(""))
+	tt.Equal(500, w.Code)
+	tt.Equal(500, zjson.Get(w.Body.String(), "code").Int())
+	tt.EqualTrue(zjson.Get(w.Body.String(), "msg").String() != "")
+
+	for _, path := range []string{"/should", "/must"} {
+		w = request(r, "POST", path, strings.NewReader("not json"), func(_ *httptest.ResponseRecorder, req *http.Request) {
+			req.Header.Set("Content-Type", mimeJSON)
+		})
+		tt.Equal(400, w.Code)
+		tt.Equal(400, zjson.Get(w.Body.String(), "code").Int())
+		tt.EqualTrue(zjson.Get(w.Body.String(), "msg").String() != "")
+	}
+
+	r.MethodNotAllowed()
+	w = request(r, "PUT", "/panic", nil)
+	tt.Equal(405, w.Code)
+	tt.Equal(`{"code":405,"msg":"405 method not allowed"}`, w.Body.String())
+
+	w = request(r, "GET", "/panic", nil)
+	tt.Equal(500, w.Code)
+	tt.Equal(`{"code":500,"msg":"boom"}`, w.Body.String())
+
+	r.OnError(nil)
+	w = request(r, "GET", "/none", nil)
+	tt.Equal(404, w.Code)
+	tt.Equal("404 page not found", w.Body.String())
+}
+
+func TestBeforeAfter(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestBeforeAfter")
+	var steps []string
+	r.Use(Recovery(nil))
+	r.Before(func(c *Context) bool {
+		steps = append(steps, "before")
+		if c.Request.URL.Path == "/deny" {
+			c.String(403, "deny")
+			return false
+		}
+		return true
+	})
+	r.After(func(c *Context) {
+		steps = append(steps, "after")
+	})
+	r.Use(func(c *Context) {
+		steps = append(steps, "middleware")
+		c.Next()
+	})
+	r.GET("/", func(c *Context) {
+		steps = append(steps, "handler")
+		c.String(200, "ok")
+	})
+	r.GET("/deny", func(c *Context) {
+		steps = append(steps, "handler")
+	})
+	r.GET("/panic", func(c *Context) {
+		panic("boom")
+	})
+
+	w := request(r, "GET", "/", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal([]string{"before", "middleware", "handler", "after"}, steps)
+
+	steps = nil
+	w = request(r, "GET", "/deny", nil)
+	tt.Equal(403, w.Code)
+	tt.Equal([]string{"before", "after"}, steps)
+
+	steps = nil
+	w = request(r, "GET", "/404", nil)
+	tt.Equal(404, w.Code)
+	tt.Equal([]string{"before", "middleware", "after"}, steps)
+
+	steps = nil
+	w = request(r, "GET", "/panic", nil)
+	tt.Equal(500, w.Code)
+	tt.Equal([]string{"before", "middleware", "after"}, steps)
+}
+
+func TestAutoOptions(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestAutoOptions")
+	r.AutoOptions("X-Token")
+	r.GET("/user/:id", func(c *Context) {})
+	r.POST("/user/:id", func(c *Context) {})
+	r.OPTIONS("/custom", func(c *Context) {
+		c.String(200, "custom")
+	})
+	r.GET("/custom", func(c *Context) {})
+
+	w := request(r, "OPTIONS", "/user/1", nil)
+	tt.Equal(204, w.Code)
+	tt.Equal("GET, POST, OPTIONS", w.Header().Get("Allow"))
+	tt.Equal("X-Token", w.Header().Get("Access-Control-Allow-Headers"))
+
+	w = request(r, "OPTIONS", "/none", nil)
+	tt.Equal(404, w.Code)
+
+	w = request(r, "OPTIONS", "/custom", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal("custom", w.Body.String())
+}
+
+func TestNotFoundAndMethodNotAllowed(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestNotFoundAndMethodNotAllowed")
+	r.Use(func(c *Context) {
+		c.SetHeader("X-Middleware", "1")
+		c.WithValue("order", "middleware")
+		c.Next()
+	})
+	r.GET("/user/:id", func(c *Context) {})
+	r.PUT("/user/:id", func(c *Context) {})
+
+	w := request(r, "POST", "/user/1", nil)
+	tt.Equal(404, w.Code)
+
+	r.NotFound(func(c *Context) {
+		v, _ := c.Value("order")
+		c.String(404, "custom 404 after "+v.(string))
+	})
+	r.MethodNotAllowed(func(c *Context) {
+		c.WithValue("methods", strings.Join(c.AllowedMethods(), ","))
+		c.Next()
+	}, func(c *Context) {
+		v, _ := c.Value("methods")
+		c.String(405, v.(string))
+	})
+
+	w = request(r, "GET", "/none", nil)
+	tt.Equal(404, w.Code)
+	tt.Equal("custom 404 after middleware", w.Body.String())
+	tt.Equal("1", w.Header().Get("X-Middleware"))
+
+	w = request(r, "POST", "/user/1", nil)
+	tt.Equal(405, w.Code)
+	tt.Equal("GET,PUT", w.Body.String())
+	tt.Equal("GET, PUT", w.Header().Get("Allow"))
+	tt.Equal("1", w.Header().Get("X-Middleware"))
+
+	w = request(r, "GET", "/user/1", nil)
+	tt.Equal(200, w.Code)
+
+	r.MethodNotAllowed()
+	w = request(r, "DELETE", "/user/1", nil)
+	tt.Equal(405, w.Code)
+	tt.Equal("405 method not allowed", w.Body.String())
+}
+
+func TestUseFor(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestUseFor")
+	r.UseFor([]string{"post"}, func(c *Context) {
+		c.SetHeader("X-Post", "1")
+		c.Next()
+	})
+	r.UseFor([]string{"GET", "POST"}, func(c *Context) {
+		c.SetHeader("X-Read-Write", "1")
+		c.Next()
+	})
+	r.Any("/", func(c *Context) {
+		c.String(200, "ok")
+	})
+
+	w := request(r, "POST", "/", nil)
+	tt.Equal("ok", w.Body.String())
+	tt.Equal("1", w.Header().Get("X-Post"))
+	tt.Equal("1", w.Header().Get("X-Read-Write"))
+
+	w = request(r, "GET", "/", nil)
+	tt.Equal("ok", w.Body.String())
+	tt.Equal("", w.Header().Get("X-Post"))
+	tt.Equal("1", w.Header().Get("X-Read-Write"))
+
+	w = request(r, "HEAD", "/", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal("", w.Header().Get("X-Post"))
+	tt.Equal("", w.Header().Get("X-Read-Write"))
+}
+
+type shortWriter struct {
+	http.ResponseWriter
+	limit int
+}
+
+func (w *shortWriter) Write(b []byte) (int, error) {
+	if len(b) > w.limit {
+		n, _ := w.ResponseWriter.Write(b[:w.limit])
+		w.limit -= n
+		return n, io.ErrShortWrite
+	}
+	w.limit -= len(b)
+	return w.ResponseWriter.Write(b)
+}
+
+type upperWriter struct {
+	http.ResponseWriter
+}
+
+func (w *upperWriter) Write(b []byte) (int, error) {
+	return w.ResponseWriter.Write(bytes.ToUpper(b))
+}
+
+func TestWrapHandler(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	type ctxKey struct{}
+	r := New("TestWrapHandler")
+	r.Use(WrapMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Frame-Options", "DENY")
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey{}, "std")))
+		})
+	}))
+	r.GET("/std", WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Std", "1")
+		w.WriteHeader(201)
+		_, _ = w.Write([]byte("std " + r.Context().Value(ctxKey{}).(string)))
+	})), func(c *Context) {
+		c.SetHeader("X-Znet", "1")
+		c.Next()
+	})
+	r.GET("/ctx", func(c *Context) {
+		c.String(200, c.Request.Context().Value(ctxKey{}).(string))
+	})
+	r.GET("/upper", func(c *Context) {
+		c.String(202, "upper")
+	}, WrapMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(&upperWriter{w}, r)
+		})
+	}))
+	r.GET("/deny", func(c *Context) {
+		c.String(200, "should not run")
+	}, WrapMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+		})
+	}))
+
+	w := request(r, "GET", "/std", nil)
+	tt.Equal(201, w.Code)
+	tt.Equal("std std", w.Body.String())
+	tt.Equal("1", w.Header().Get("X-Std"))
+	tt.Equal("1", w.Header().Get("X-Znet"))
+	tt.Equal("DENY", w.Header().Get("X-Frame-Options"))
+
+	w = request(r, "GET", "/ctx", nil)
+	tt.Equal(200, w.Code)
+	tt.Equal("std", w.Body.String())
+	tt.Equal("DENY", w.Header().Get("X-Frame-Options"))
+
+	w = request(r, "GET", "/upper", nil)
+	tt.Equal(202, w.Code)
+	tt.Equal("UPPER", w.Body.String())
+
+	w = request(r, "GET", "/deny", nil)
+	tt.Equal(401, w.Code)
+	tt.Equal("unauthorized\n", w.Body.String())
+}
+
+func TestSSEvent(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestSSEvent")
+	after := false
+	r.Use(func(c *Context) {
+		c.Next()
+		c.String(500, "late")
+	})
+	r.GET("/", func(c *Context) {
+		c.SetHeader("X-Custom", "1")
+		c.String(200, "buffered")
+		tt.NoError(c.SSEvent("message", "hello"))
+		after = true
+		tt.NoError(c.SSEvent("", "line1\nline2"))
+	})
+
+	w := request(r, "GET", "/", nil)
+	tt.Equal(200, w.Code)
+	tt.EqualTrue(w.Flushed)
+	h := w.Result().Header
+	tt.Equal("text/event-stream", h.Get("Content-Type"))
+	tt.Equal("no", h.Get("X-Accel-Buffering"))
+	tt.Equal("no-cache", h.Get("Cache-Control"))
+	tt.Equal("1", h.Get("X-Custom"))
+	tt.Equal("", h.Get("Content-Length"))
+	tt.Equal("event: message\ndata: hello\n\ndata: line1\ndata: line2\n\n", w.Body.String())
+	tt.EqualTrue(after)
+
+	rec := httptest.NewRecorder()
+	s := NewSSEWriter(rec)
+	tt.NoError(s.Send("1", "update", "zlsgo"))
+	tt.Equal("id: 1\nevent: update\ndata: zlsgo\n\n", rec.Body.String())
+	tt.Equal("text/event-stream", rec.Header().Get("Content-Type"))
+}
+
+func TestAutoHead(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestAutoHead")
+	r.GET("/user/:id", func(c *Context) {
+		c.SetHeader("X-User", c.GetParam("id"))
+		c.String(201, "user")
+	})
+	r.GET("/custom", func(c *Context) {
+		c.String(200, "get")
+	})
+	r.HEAD("/custom", func(c *Context) {
+		c.SetHeader("X-Head", "1")
+		c.String(202, "head")
+	})
+
+	get := request(r, "GET", "/user/1", nil)
+	head := request(r, "HEAD", "/user/1", nil)
+	tt.Equal(get.Code, head.Code)
+	tt.Equal(get.Header(), head.Header())
+	tt.Equal("4", head.Header().Get("Content-Length"))
+	tt.Equal("user", get.Body.String())
+	tt.Equal("", head.Body.String())
+
+	w := request(r, "HEAD", "/custom", nil)
+	tt.Equal(202, w.Code)
+	tt.Equal("1", w.Header().Get("X-Head"))
+	tt.Equal("", w.Body.String())
+
+	w = request(r, "HEAD", "/none", nil)
+	tt.Equal(404, w.Code)
+}
@@ -0,0 +1,404 @@
+package znet
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WebSocket message types, the values match RFC 6455 opcodes
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+// WebSocket close codes
+const (
+	CloseNormalClosure    = 1000
+	CloseGoingAway        = 1001
+	CloseProtocolError    = 1002
+	CloseNoStatusReceived = 1005
+	CloseInvalidPayload   = 1007
+	CloseMessageTooBig    = 1009
+)
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var (
+	// ErrWebSocketClosed the connection has been closed
+	ErrWebSocketClosed = errors.New("websocket: connection closed")
+	// ErrWebSocketReadLimit a message exceeded the read limit
+	ErrWebSocketReadLimit = errors.New("websocket: read limit exceeded")
+)
+
+type (
+	// WebSocketUpgrader options for Context.Upgrade
+	WebSocketUpgrader struct {
+		// CheckOrigin reports whether the request origin is allowed,
+		// nil only allows requests without Origin or from the same host
+		CheckOrigin func(r *http.Request) bool
+		// Subprotocols supported by the server in order of preference
+		Subprotocols []string
+		// ReadLimit maximum size of a message in bytes, defaults to 32MB
+		ReadLimit int64
+	}
+
+	// WebSocketConn a server side websocket connection
+	WebSocketConn struct {
+		conn        net.Conn
+		br          *bufio.Reader
+		pingHandler func(appData string) error
+		subprotocol string
+		readLimit   int64
+		writeMu     sync.Mutex
+		pingMu      sync.RWMutex
+		closeOnce   sync.Once
+	}
+
+	// WebSocketCloseError returned by ReadMessage when the peer closes the connection
+	WebSocketCloseError struct {
+		Text string
+		Code int
+	}
+)
+
+func (e *WebSocketCloseError) Error() string {
+	s := "websocket: close " + strconv.Itoa(e.Code)
+	if e.Text != "" {
+		s += " " + e.Text
+	}
+	return s
+}
+
+// Upgrade upgrade the request to a websocket connection, an error returned before
+// the connection is hijacked has already been answered with an error response
+func (c *Context) Upgrade(upgrader WebSocketUpgrader) (*WebSocketConn, error) {
+	r := c.Request
+	if r.Method != http.MethodGet {
+		return nil, c.upgradeError(http.StatusMethodNotAllowed, "websocket: request method is not GET")
+	}
+	if !c.IsWebsocket() {
+		return nil, c.upgradeError(http.StatusBadRequest, "websocket: not a websocket handshake")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		c.SetHeader("Sec-WebSocket-Version", "13")
+		return nil, c.upgradeError(http.StatusBadRequest, "websocket: unsupported version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		return nil, c.upgradeError(http.StatusBadRequest, "websocket: missing Sec-WebSocket-Key")
+	}
+	checkOrigin := upgrader.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = sameOrigin
+	}
+	if !checkOrigin(r) {
+		return nil, c.upgradeError(http.StatusForbidden, "websocket: origin not allowed")
+	}
+
+	h, ok := c.Writer.(http.Hijacker)
+	if !ok {
+		return nil, c.upgradeError(http.StatusInternalServerError, "websocket: response does not implement http.Hijacker")
+	}
+
+	subprotocol := selectSubprotocol(r, upgrader.Subprotocols)
+	conn, brw, err := h.Hijack()
+	if err != nil {
+		_ = c.upgradeError(http.StatusInternalServerError, "")
+		return nil, err
+	}
+	c.streamed = true
+	c.Abort()
+	_ = conn.SetDeadline(time.Time{})
+
+	res := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: " +
+		websocketAccept(key) + "\r\n"
+	if subprotocol != "" {
+		res += "Sec-WebSocket-Protocol: " + subprotocol + "\r\n"
+	}
+	if _, err = conn.Write([]byte(res + "\r\n")); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	readLimit := upgrader.ReadLimit
+	if readLimit <= 0 {
+		readLimit = 32 << 20
+	}
+
+	return &WebSocketConn{
+		conn:        conn,
+		br:          brw.Reader,
+		subprotocol: subprotocol,
+		readLimit:   readLimit,
+	}, nil
+}
+
+func (c *Context) upgradeError(code int32, msg string) error {
+	c.String(code, http.StatusText(int(code)))
+	c.Abort()
+	return errors.New(msg)
+}
+
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+func selectSubprotocol(r *http.Request, supported []string) string {
+	for _, requested := range strings.Split(r.Header.Get("Sec-WebSocket-Protocol"), ",") {
+		requested = strings.TrimSpace(requested)
+		for i := range supported {
+			if requested != "" && requested == supported[i] {
+				return requested
+			}
+		}
+	}
+	return ""
+}
+
+func websocketAccept(key string) string {
+	h := sha1.New()
+	_, _ = h.Write([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// Subprotocol the negotiated subprotocol
+func (w *WebSocketConn) Subprotocol() string {
+	return w.subprotocol
+}
+
+// RemoteAddr the remote network address
+func (w *WebSocketConn) RemoteAddr() net.Addr {
+	return w.conn.RemoteAddr()
+}
+
+// SetPingHandler set the handler for ping messages, nil restores the default that replies with a pong
+func (w *WebSocketConn) SetPingHandler(h func(appData string) error) {
+	w.pingMu.Lock()
+	w.pingHandler = h
+	w.pingMu.Unlock()
+}
+
+// ReadMessage read the next text or binary message, control messages are handled internally,
+// a close from the peer is answered and returned as *WebSocketCloseError
+func (w *WebSocketConn) ReadMessage() (messageType int, p []byte, err error) {
+	for {
+		var (
+			fin     bool
+			opcode  int
+			payload []byte
+		)
+		fin, opcode, payload, err = w.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch opcode {
+		case PingMessage:
+			if err = w.handlePing(payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			closeErr := &WebSocketCloseError{Code: CloseNoStatusReceived}
+			if len(payload) >= 2 {
+				closeErr.Code = int(binary.BigEndian.Uint16(payload))
+				closeErr.Text = string(payload[2:])
+			}
+			_ = w.close(CloseNormalClosure, "")
+			return 0, nil, closeErr
+		case TextMessage, BinaryMessage:
+		default:
+			return 0, nil, w.fail(CloseProtocolError, errors.New("websocket: unexpected opcode "+strconv.Itoa(opcode)))
+		}
+
+		messageType, p = opcode, payload
+		for !fin {
+			var next []byte
+			fin, opcode, next, err = w.readFrame()
+			if err != nil {
+				return 0, nil, err
+			}
+			switch opcode {
+			case 0:
+				if int64(len(p)+len(next)) > w.readLimit {
+					return 0, nil, w.fail(CloseMessageTooBig, ErrWebSocketReadLimit)
+				}
+				p = append(p, next...)
+			case PingMessage:
+				if err = w.handlePing(next); err != nil {
+					return 0, nil, err
+				}
+			case PongMessage:
+			default:
+				return 0, nil, w.fail(CloseProtocolError, errors.New("websocket: unexpected opcode in fragmented message"))
+			}
+		}
+
+		if messageType == TextMessage && !utf8.Valid(p) {
+			return 0, nil, w.fail(CloseInvalidPayload, errors.New("websocket: invalid utf-8 in text message"))
+		}
+		return messageType, p, nil
+	}
+}
+
+func (w *WebSocketConn) handlePing(payload []byte) error {
+	w.pingMu.RLock()
+	h := w.pingHandler
+	w.pingMu.RUnlock()
+	if h != nil {
+		return h(string(payload))
+	}
+	return w.WriteMessage(PongMessage, payload)
+}
+
+func (w *WebSocketConn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(w.br, head[:]); err != nil {
+		return
+	}
+	fin = head[0]&0x80 != 0
+	opcode = int(head[0] & 0x0f)
+	if head[0]&0x70 != 0 {
+		err = w.fail(CloseProtocolError, errors.New("websocket: reserved bits set"))
+		return
+	}
+	if head[1]&0x80 == 0 {
+		err = w.fail(CloseProtocolError, errors.New("websocket: client frame is not masked"))
+		return
+	}
+
+	length := int64(head[1] & 0x7f)
+	switch length {
+	case 126:
+		var b [2]byte
+		if _, err = io.ReadFull(w.br, b[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint16(b[:]))
+	case 127:
+		var b [8]byte
+		if _, err = io.ReadFull(w.br, b[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint64(b[:]))
+	}
+	if opcode >= CloseMessage && (length > 125 || !fin) {
+		err = w.fail(CloseProtocolError, errors.New("websocket: invalid control frame"))
+		return
+	}
+	if length < 0 || length > w.readLimit {
+		err = w.fail(CloseMessageTooBig, ErrWebSocketReadLimit)
+		return
+	}
+
+	var mask [4]byte
+	if _, err = io.ReadFull(w.br, mask[:]); err != nil {
+		return
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(w.br, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return
+}
+
+func (w *WebSocketConn) fail(code int, err error) error {
+	_ = w.close(code, "")
+	return err
+}
+
+// WriteMessage write a single message, control messages are limited to 125 bytes
+func (w *WebSocketConn) WriteMessage(messageType int, data []byte) error {
+	switch messageType {
+	case TextMessage, BinaryMessage:
+	case CloseMessage, PingMessage, PongMessage:
+		if len(data) > 125 {
+			return errors.New("websocket: control message too long")
+		}
+	default:
+		return errors.New("websocket: unknown message type " + strconv.Itoa(messageType))
+	}
+
+	frame := make([]byte, 0, len(data)+10)
+	frame = append(frame, 0x80|byte(messageType))
+	switch l := len(data); {
+	case l <= 125:
+		frame = append(frame, byte(l))
+	case l <= 0xffff:
+		frame = append(frame, 126, byte(l>>8), byte(l))
+	default:
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(l))
+		frame = append(frame, 127)
+		frame = append(frame, b[:]...)
+	}
+	frame = append(frame, data...)
+
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	_, err := w.conn.Write(frame)
+	return err
+}
+
+func (w *WebSocketConn) writeClose(code int, text string) error {
+	// a control frame payload is at most 125 bytes, 2 of them hold the code
+	if len(text) > 123 {
+		i := 123
+		for i > 0 && !utf8.RuneStart(text[i]) {
+			i--
+		}
+		text = text[:i]
+	}
+	data := make([]byte, 2, 2+len(text))
+	binary.BigEndian.PutUint16(data, uint16(code))
+	data = append(data, text...)
+	return w.WriteMessage(CloseMessage, data)
+}
+
+// Close send a normal close message and close the underlying connection
+func (w *WebSocketConn) Close() error {
+	return w.close(CloseNormalClosure, "")
+}
+
+// CloseWithReason send a close message with code and reason and close the underlying connection,
+// the reason is truncated to the 123 bytes allowed in a close frame
+func (w *WebSocketConn) CloseWithReason(code int, reason string) error {
+	return w.close(code, reason)
+}
+
+func (w *WebSocketConn) close(code int, text string) error {
+	err := ErrWebSocketClosed
+	w.closeOnce.Do(func() {
+		_ = w.writeClose(code, text)
+		err = w.conn.Close()
+	})
+	return err
+}
@@ -0,0 +1,297 @@
+package znet
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sohaha/zlsgo"
+)
+
+type testWSClient struct {
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+func dialTestWS(t *testing.T, addr, path string, header map[string]string) (*testWSClient, *http.Response) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	req := "GET " + path + " HTTP/1.1\r\nHost: " + addr + "\r\n"
+	h := map[string]string{
+		"Upgrade":               "websocket",
+		"Connection":            "Upgrade",
+		"Sec-WebSocket-Key":     "dGhlIHNhbXBsZSBub25jZQ==",
+		"Sec-WebSocket-Version": "13",
+	}
+	for k, v := range header {
+		h[k] = v
+	}
+	for k, v := range h {
+		if v != "" {
+			req += k + ": " + v + "\r\n"
+		}
+	}
+	_, _ = conn.Write([]byte(req + "\r\n"))
+	br := bufio.NewReader(conn)
+	res, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testWSClient{conn: conn, br: br}, res
+}
+
+func (c *testWSClient) write(opcode byte, data []byte) {
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode}
+	if l := len(data); l <= 125 {
+		frame = append(frame, 0x80|byte(l))
+	} else {
+		frame = append(frame, 0x80|126, byte(l>>8), byte(l))
+	}
+	frame = append(frame, mask[:]...)
+	for i := range data {
+		frame = append(frame, data[i]^mask[i%4])
+	}
+	_, _ = c.conn.Write(frame)
+}
+
+func (c *testWSClient) read() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.br, head[:]); err != nil {
+		return 0, nil, err
+	}
+	l := int(head[1] & 0x7f)
+	if l == 126 {
+		var b [2]byte
+		_, _ = io.ReadFull(c.br, b[:])
+		l = int(binary.BigEndian.Uint16(b[:]))
+	}
+	data := make([]byte, l)
+	_, err := io.ReadFull(c.br, data)
+	return head[0] & 0x0f, data, err
+}
+
+func TestWebSocketUpgrade(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestWebSocketUpgrade")
+	pings := make(chan string, 1)
+	closed := make(chan error, 1)
+	r.GET("/ws", func(c *Context) {
+		ws, err := c.Upgrade(WebSocketUpgrader{Subprotocols: []string{"chat"}})
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		for {
+			mt, p, err := ws.ReadMessage()
+			if err != nil {
+				closed <- err
+				return
+			}
+			if string(p) == "custom ping" {
+				ws.SetPingHandler(func(appData string) error {
+					pings <- appData
+					return nil
+				})
+				continue
+			}
+			if err = ws.WriteMessage(mt, append([]byte("echo:"), p...)); err != nil {
+				return
+			}
+		}
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	c, res := dialTestWS(t, addr, "/ws", map[string]string{"Sec-WebSocket-Protocol": "json, chat"})
+	defer c.conn.Close()
+	tt.Equal(http.StatusSwitchingProtocols, res.StatusCode)
+	tt.Equal("s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", res.Header.Get("Sec-WebSocket-Accept"))
+	tt.Equal("chat", res.Header.Get("Sec-WebSocket-Protocol"))
+
+	c.write(TextMessage, []byte("hello"))
+	op, data, err := c.read()
+	tt.NoError(err)
+	tt.Equal(byte(TextMessage), op)
+	tt.Equal("echo:hello", string(data))
+
+	long := strings.Repeat("z", 300)
+	c.write(BinaryMessage, []byte(long))
+	op, data, err = c.read()
+	tt.NoError(err)
+	tt.Equal(byte(BinaryMessage), op)
+	tt.Equal("echo:"+long, string(data))
+
+	c.write(PingMessage, []byte("p1"))
+	op, data, err = c.read()
+	tt.NoError(err)
+	tt.Equal(byte(PongMessage), op)
+	tt.Equal("p1", string(data))
+
+	c.write(TextMessage, []byte("custom ping"))
+	c.write(PingMessage, []byte("p2"))
+	tt.Equal("p2", <-pings)
+
+	c.write(CloseMessage, []byte{0x03, 0xe8, 'b', 'y', 'e'})
+	op, data, err = c.read()
+	tt.NoError(err)
+	tt.Equal(byte(CloseMessage), op)
+	tt.Equal(CloseNormalClosure, int(binary.BigEndian.Uint16(data)))
+
+	closeErr, ok := (<-closed).(*WebSocketCloseError)
+	tt.EqualTrue(ok)
+	tt.Equal(CloseNormalClosure, closeErr.Code)
+	tt.Equal("bye", closeErr.Text)
+}
+
+func TestWebSocketClose(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestWebSocketClose")
+	reason := strings.Repeat("é", 100)
+	readErr := make(chan error, 1)
+	r.GET("/reason", func(c *Context) {
+		ws, err := c.Upgrade(WebSocketUpgrader{})
+		if err != nil {
+			return
+		}
+		_ = ws.CloseWithReason(CloseGoingAway, reason)
+	})
+	r.GET("/utf8", func(c *Context) {
+		ws, err := c.Upgrade(WebSocketUpgrader{})
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		_, _, err = ws.ReadMessage()
+		readErr <- err
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	c, res := dialTestWS(t, addr, "/reason", nil)
+	tt.Equal(http.StatusSwitchingProtocols, res.StatusCode)
+	op, data, err := c.read()
+	_ = c.conn.Close()
+	tt.NoError(err)
+	tt.Equal(byte(CloseMessage), op)
+	tt.EqualTrue(len(data) <= 125)
+	tt.Equal(CloseGoingAway, int(binary.BigEndian.Uint16(data)))
+	tt.EqualTrue(utf8.Valid(data[2:]))
+	tt.Equal(reason[:122], string(data[2:]))
+
+	c, res = dialTestWS(t, addr, "/utf8", nil)
+	defer c.conn.Close()
+	tt.Equal(http.StatusSwitchingProtocols, res.StatusCode)
+	c.write(TextMessage, []byte{'o', 'k', 0xff, 0xfe})
+	op, data, err = c.read()
+	tt.NoError(err)
+	tt.Equal(byte(CloseMessage), op)
+	tt.Equal(CloseInvalidPayload, int(binary.BigEndian.Uint16(data)))
+	tt.EqualTrue(<-readErr != nil)
+}
+
+func TestWebSocketUpgradeFail(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestWebSocketUpgradeFail")
+	r.GET("/ws", func(c *Context) {
+		_, _ = c.Upgrade(WebSocketUpgrader{})
+	})
+	r.GET("/any", func(c *Context) {
+		_, _ = c.Upgrade(WebSocketUpgrader{CheckOrigin: func(r *http.Request) bool {
+			return true
+		}})
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	c, res := dialTestWS(t, addr, "/ws", map[string]string{"Origin": "http://example.com"})
+	_ = c.conn.Close()
+	tt.Equal(http.StatusForbidden, res.StatusCode)
+
+	c, res = dialTestWS(t, addr, "/any", map[string]string{"Origin": "http://example.com"})
+	_ = c.conn.Close()
+	tt.Equal(http.StatusSwitchingProtocols, res.StatusCode)
+
+	c, res = dialTestWS(t, addr, "/ws", map[string]string{"Sec-WebSocket-Version": "8"})
+	_ = c.conn.Close()
+	tt.Equal(http.StatusBadRequest, res.StatusCode)
+	tt.Equal("13", res.Header.Get("Sec-WebSocket-Version"))
+
+	c, res = dialTestWS(t, addr, "/ws", map[string]string{"Sec-WebSocket-Key": ""})
+	_ = c.conn.Close()
+	tt.Equal(http.StatusBadRequest, res.StatusCode)
+
+	w := request(r, "GET", "/ws", nil)
+	tt.Equal(http.StatusBadRequest, w.Code)
+}
+
+type hijackRecorder struct {
+	http.ResponseWriter
+	conn     net.Conn
+	hijacked bool
+	writes   int
+}
+
+func (w *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+	return w.conn, bufio.NewReadWriter(bufio.NewReader(w.conn), bufio.NewWriter(w.conn)), nil
+}
+
+func (w *hijackRecorder) WriteHeader(code int) {
+	if w.hijacked {
+		w.writes++
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *hijackRecorder) Write(b []byte) (int, error) {
+	if w.hijacked {
+		w.writes++
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func TestWebSocketUpgradeMiddleware(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	r := New("TestWebSocketUpgradeMiddleware")
+	r.Use(func(c *Context) {
+		c.Next()
+		c.String(http.StatusInternalServerError, "late")
+	})
+	r.GET("/ws", func(c *Context) {
+		ws, err := c.Upgrade(WebSocketUpgrader{})
+		if err != nil {
+			return
+		}
+		_ = ws.Close()
+		c.SetStatus(http.StatusTeapot)
+	})
+
+	server, client := net.Pipe()
+	go func() { _, _ = io.Copy(io.Discard, client) }()
+	defer client.Close()
+
+	w := &hijackRecorder{ResponseWriter: httptest.NewRecorder(), conn: server}
+	req, _ := http.NewRequest("GET", "/ws", nil)
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	r.ServeHTTP(w, req)
+
+	tt.EqualTrue(w.hijacked)
+	tt.Equal(0, w.writes)
+}
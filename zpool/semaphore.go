@@ -0,0 +1,47 @@
+package zpool
+
+import (
+	"context"
+)
+
+// Semaphore bounded semaphore for limiting concurrency
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore create a semaphore that allows n concurrent holders
+func NewSemaphore(n int) *Semaphore {
+	if n <= 0 {
+		n = 1
+	}
+	return &Semaphore{slots: make(chan struct{}, n)}
+}
+
+// Acquire blocks until a slot is free or ctx is done
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquire a slot without blocking, returns false when full
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release release a slot acquired by Acquire or TryAcquire
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+	default:
+		panic("zpool: semaphore released more than acquired")
+	}
+}
@@ -0,0 +1,82 @@
+package zpool_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sohaha/zlsgo"
+	"github.com/sohaha/zlsgo/zpool"
+	"github.com/sohaha/zlsgo/zutil"
+)
+
+func TestSemaphore(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	n := 3
+	s := zpool.NewSemaphore(n)
+	running, max := zutil.NewInt32(0), zutil.NewInt32(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n*3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tt.NoError(s.Acquire(context.Background()))
+			defer s.Release()
+			r := running.Add(1)
+			for {
+				m := max.Load()
+				if r <= m || max.CAS(m, r) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond * 20)
+			running.Add(-1)
+		}()
+	}
+	wg.Wait()
+	tt.Equal(int32(n), max.Load())
+}
+
+func TestSemaphoreBlock(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	s := zpool.NewSemaphore(2)
+	tt.EqualTrue(s.TryAcquire())
+	tt.NoError(s.Acquire(context.Background()))
+	tt.EqualTrue(!s.TryAcquire())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	err := s.Acquire(ctx)
+	tt.Equal(context.DeadlineExceeded, err)
+
+	acquired := make(chan struct{})
+	go func() {
+		_ = s.Acquire(context.Background())
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquire should block")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	s.Release()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("acquire should be released")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Acquire(ctx)
+	}()
+	cancel()
+	tt.Equal(context.Canceled, <-done)
+}
@@ -2,7 +2,9 @@ package zreflect
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 )
 
 func ForEachMethod(valof reflect.Value, fn func(index int, method reflect.Method, value reflect.Value) error) error {
@@ -28,6 +30,43 @@ var (
 	SkipChild = errors.New("skip struct")
 )
 
+var typeHandlers sync.Map
+
+// RegisterTypeHandler register a handler for type t, ForEach treats t as a leaf
+// and ForEachValue and WalkFields pass the handler result instead of the raw value,
+// a nil result is passed as the zero value of t, a nil fn removes the handler
+func RegisterTypeHandler(t reflect.Type, fn func(v reflect.Value) (interface{}, error)) {
+	if fn == nil {
+		typeHandlers.Delete(t)
+		return
+	}
+	typeHandlers.Store(t, fn)
+}
+
+func getTypeHandler(t reflect.Type) (func(v reflect.Value) (interface{}, error), bool) {
+	fn, ok := typeHandlers.Load(t)
+	if !ok {
+		return nil, false
+	}
+	return fn.(func(v reflect.Value) (interface{}, error)), true
+}
+
+func callTypeHandler(fn func(v reflect.Value) (interface{}, error), v reflect.Value) (res reflect.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("type handler %s: %v", v.Type(), r)
+		}
+	}()
+	i, err := fn(v)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if i == nil {
+		return reflect.Zero(v.Type()), nil
+	}
+	return reflect.ValueOf(i), nil
+}
+
 // ForEach For Each Struct field
 func ForEach(typ reflect.Type, fn func(parent []string, index int, tag string, field reflect.StructField) error) (err error) {
 	var forField func(typ reflect.Type, parent []string) error
@@ -42,7 +81,9 @@ func ForEach(typ reflect.Type, fn func(parent []string, index int, tag string, f
 			}
 
 			if err == nil && field.Type.Kind() == reflect.Struct {
-				err = forField(field.Type, append(parent, fieldTag))
+				if _, ok := getTypeHandler(field.Type); !ok {
+					err = forField(field.Type, append(parent, fieldTag))
+				}
 			}
 
 			if err != nil {
@@ -52,6 +93,9 @@ func ForEach(typ reflect.Type, fn func(parent []string, index int, tag string, f
 		return nil
 	}
 
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
 	return forField(typ, []string{})
 }
 
@@ -72,12 +116,20 @@ func ForEachValue(val reflect.Value, fn func(parent []string, index int, tag str
 				continue
 			}
 
+			handler, handled := getTypeHandler(field.Type)
+			if handled {
+				fieldValue, err = callTypeHandler(handler, fieldValue)
+				if err != nil {
+					return err
+				}
+			}
+
 			err = fn(parent, i, fieldTag, field, fieldValue)
 			if err == SkipChild {
 				continue
 			}
 
-			if err == nil && field.Type.Kind() == reflect.Struct {
+			if err == nil && !handled && field.Type.Kind() == reflect.Struct {
 				err = forField(fieldValue, field.Type, append(parent, fieldTag))
 			}
 
@@ -91,3 +143,93 @@ func ForEachValue(val reflect.Value, fn func(parent []string, index int, tag str
 
 	return forField(val, typ, []string{})
 }
+
+// ErrCyclic is returned by WalkFields when a struct references itself.
+var ErrCyclic = errors.New("cyclic struct reference")
+
+// WalkFields visits every exported leaf field of a struct with its full path, structs without
+// exported fields (such as time.Time) or with a registered type handler are leaves,
+// pointer fields are followed and nil pointers are skipped
+func WalkFields(v interface{}, fn func(path []string, field reflect.StructField, value reflect.Value) error) error {
+	val, ok := v.(reflect.Value)
+	if !ok {
+		val = reflect.ValueOf(v)
+	}
+
+	visited := make(map[uintptr]struct{})
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return errors.New("value is nil pointer")
+		}
+		visited[val.Pointer()] = struct{}{}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return errors.New("value must be struct")
+	}
+
+	var walk func(val reflect.Value, parent []string) error
+	walk = func(val reflect.Value, parent []string) error {
+		typ := val.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			fieldValue := val.Field(i)
+			path := make([]string, len(parent)+1)
+			copy(path, parent)
+			path[len(parent)] = field.Name
+
+			var ptrs []uintptr
+			for fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+				p := fieldValue.Pointer()
+				if _, ok := visited[p]; ok {
+					return ErrCyclic
+				}
+				ptrs = append(ptrs, p)
+				fieldValue = fieldValue.Elem()
+			}
+
+			if fieldValue.Kind() == reflect.Ptr {
+				continue
+			}
+
+			var err error
+			if handler, ok := getTypeHandler(fieldValue.Type()); ok {
+				fieldValue, err = callTypeHandler(handler, fieldValue)
+				if err == nil {
+					err = fn(path, field, fieldValue)
+				}
+			} else if fieldValue.Kind() == reflect.Struct && hasExportedField(fieldValue.Type()) {
+				for _, p := range ptrs {
+					visited[p] = struct{}{}
+				}
+				err = walk(fieldValue, path)
+				for _, p := range ptrs {
+					delete(visited, p)
+				}
+			} else {
+				err = fn(path, field, fieldValue)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return walk(val, []string{})
+}
+
+func hasExportedField(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
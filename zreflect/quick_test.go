@@ -1,8 +1,11 @@
 package zreflect
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sohaha/zlsgo"
 )
@@ -49,3 +52,138 @@ func TestForEachValue(t *testing.T) {
 	})
 	tt.NoError(err)
 }
+
+func TestWalkFields(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	type (
+		flat struct {
+			Name string
+			Age  int
+			pri  string
+		}
+		child struct {
+			Title string
+		}
+		nested struct {
+			At     time.Time
+			Child  child
+			Ptr    *child
+			Nil    *child
+			Remark string
+		}
+		cyclic struct {
+			Name string
+			Next *cyclic
+		}
+	)
+
+	tt.Run("flat", func(tt *zlsgo.TestUtil) {
+		var paths []string
+		err := WalkFields(flat{Name: "zls", Age: 18, pri: "pri"}, func(path []string, field reflect.StructField, value reflect.Value) error {
+			paths = append(paths, strings.Join(path, "."))
+			return nil
+		})
+		tt.NoError(err)
+		tt.Equal([]string{"Name", "Age"}, paths)
+	})
+
+	tt.Run("nested", func(tt *zlsgo.TestUtil) {
+		values := map[string]interface{}{}
+		now := time.Now()
+		err := WalkFields(&nested{At: now, Child: child{Title: "a"}, Ptr: &child{Title: "b"}, Remark: "c"}, func(path []string, field reflect.StructField, value reflect.Value) error {
+			values[strings.Join(path, ".")] = value.Interface()
+			return nil
+		})
+		tt.NoError(err)
+		tt.Equal(map[string]interface{}{"At": now, "Child.Title": "a", "Ptr.Title": "b", "Remark": "c"}, values)
+	})
+
+	tt.Run("cyclic", func(tt *zlsgo.TestUtil) {
+		c := &cyclic{Name: "a"}
+		c.Next = &cyclic{Name: "b", Next: c}
+		err := WalkFields(c, func(path []string, field reflect.StructField, value reflect.Value) error {
+			return nil
+		})
+		tt.Equal(ErrCyclic, err)
+	})
+
+	tt.Run("error", func(tt *zlsgo.TestUtil) {
+		tt.EqualTrue(WalkFields(1, nil) != nil)
+		e := errors.New("stop")
+		err := WalkFields(flat{}, func(path []string, field reflect.StructField, value reflect.Value) error {
+			return e
+		})
+		tt.Equal(e, err)
+	})
+}
+
+type testDecimal struct {
+	units int64
+	scale int
+}
+
+func TestRegisterTypeHandler(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	typ := reflect.TypeOf(testDecimal{})
+	type order struct {
+		Price testDecimal
+		At    struct{ Day int }
+	}
+	v := order{Price: testDecimal{units: 1250, scale: 2}}
+	v.At.Day = 3
+
+	collect := func() (map[string]interface{}, error) {
+		res := map[string]interface{}{}
+		err := WalkFields(&v, func(path []string, field reflect.StructField, value reflect.Value) error {
+			res[strings.Join(path, ".")] = value.Interface()
+			return nil
+		})
+		return res, err
+	}
+
+	RegisterTypeHandler(typ, func(v reflect.Value) (interface{}, error) {
+		d := v.Interface().(testDecimal)
+		return float64(d.units) / 100, nil
+	})
+	res, err := collect()
+	tt.NoError(err)
+	tt.Equal(map[string]interface{}{"Price": 12.5, "At.Day": 3}, res)
+
+	var paths []string
+	tt.NoError(ForEach(reflect.TypeOf(v), func(parent []string, index int, tag string, field reflect.StructField) error {
+		paths = append(paths, strings.Join(append(parent, tag), "."))
+		return nil
+	}))
+	tt.Equal([]string{"Price", "At", "At.Day"}, paths)
+
+	tt.NoError(ForEachValue(reflect.ValueOf(v), func(parent []string, index int, tag string, field reflect.StructField, val reflect.Value) error {
+		if tag == "Price" {
+			tt.Equal(12.5, val.Interface())
+		}
+		return nil
+	}))
+
+	RegisterTypeHandler(typ, func(v reflect.Value) (interface{}, error) {
+		return nil, nil
+	})
+	res, err = collect()
+	tt.NoError(err)
+	tt.Equal(map[string]interface{}{"Price": testDecimal{}, "At.Day": 3}, res)
+	tt.NoError(ForEachValue(reflect.ValueOf(v), func(parent []string, index int, tag string, field reflect.StructField, val reflect.Value) error {
+		tt.EqualTrue(val.IsValid())
+		return nil
+	}))
+
+	RegisterTypeHandler(typ, func(v reflect.Value) (interface{}, error) {
+		panic("bad decimal")
+	})
+	_, err = collect()
+	tt.EqualTrue(err != nil)
+	tt.EqualTrue(strings.Contains(err.Error(), "bad decimal"))
+
+	RegisterTypeHandler(typ, nil)
+	res, err = collect()
+	tt.NoError(err)
+	tt.Equal(map[string]interface{}{"Price": v.Price, "At.Day": 3}, res)
+}
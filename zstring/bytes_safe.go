@@ -0,0 +1,16 @@
+//go:build !zlsgo_unsafe
+// +build !zlsgo_unsafe
+
+package zstring
+
+// Bytes string to bytes, the result is a copy,
+// build with the zlsgo_unsafe tag to share memory like String2Bytes
+func Bytes(s string) []byte {
+	return []byte(s)
+}
+
+// String bytes to string, the result is a copy,
+// build with the zlsgo_unsafe tag to share memory like Bytes2String
+func String(b []byte) string {
+	return string(b)
+}
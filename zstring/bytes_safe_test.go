@@ -0,0 +1,23 @@
+//go:build !zlsgo_unsafe
+// +build !zlsgo_unsafe
+
+package zstring
+
+import (
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestBytesCopy(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	b := []byte("zlsgo")
+	s := String(b)
+	b[0] = 'Z'
+	tt.Equal("zlsgo", s)
+
+	nb := Bytes(s)
+	nb[0] = 'Z'
+	tt.Equal("zlsgo", s)
+}
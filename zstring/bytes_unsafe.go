@@ -0,0 +1,14 @@
+//go:build zlsgo_unsafe
+// +build zlsgo_unsafe
+
+package zstring
+
+// Bytes string to bytes without copying, the result must not be modified
+func Bytes(s string) []byte {
+	return String2Bytes(s)
+}
+
+// String bytes to string without copying, b must not be modified afterwards
+func String(b []byte) string {
+	return Bytes2String(b)
+}
@@ -0,0 +1,21 @@
+//go:build zlsgo_unsafe
+// +build zlsgo_unsafe
+
+package zstring
+
+import (
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestBytesNoAlloc(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	s := "zlsgo"
+	b := []byte(s)
+	tt.Equal(0.0, testing.AllocsPerRun(100, func() {
+		_ = Bytes(s)
+		_ = String(b)
+	}))
+}
@@ -0,0 +1,81 @@
+package zstring
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EqualFold reports whether a and b are equal under Unicode case-folding
+func EqualFold(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// CompareIgnoreCase compare a and b ignoring case, returns -1, 0 or 1
+func CompareIgnoreCase(a, b string) int {
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if ra, rb = unicode.ToLower(ra), unicode.ToLower(rb); ra != rb {
+			if ra < rb {
+				return -1
+			}
+			return 1
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return compareLen(len(a), len(b))
+}
+
+// CompareNatural compare a and b in natural order, digit runs are compared by their numeric value,
+// so "file2" sorts before "file10", equal values fall back to byte order, returns -1, 0 or 1
+func CompareNatural(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ca, cb := a[i], b[j]
+		if isDigit(ca) && isDigit(cb) {
+			si, sj := i, j
+			for i < len(a) && isDigit(a[i]) {
+				i++
+			}
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+			na := strings.TrimLeft(a[si:i], "0")
+			nb := strings.TrimLeft(b[sj:j], "0")
+			if r := compareLen(len(na), len(nb)); r != 0 {
+				return r
+			}
+			if r := strings.Compare(na, nb); r != 0 {
+				return r
+			}
+			continue
+		}
+		if ca != cb {
+			if ca < cb {
+				return -1
+			}
+			return 1
+		}
+		i++
+		j++
+	}
+	if r := compareLen(len(a)-i, len(b)-j); r != 0 {
+		return r
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func compareLen(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
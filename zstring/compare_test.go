@@ -0,0 +1,43 @@
+package zstring
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestEqualFold(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tt.EqualTrue(EqualFold("ZlsGo", "zlsgo"))
+	tt.EqualTrue(EqualFold("Ωmega", "ωMEGA"))
+	tt.EqualTrue(!EqualFold("zls", "zlsgo"))
+}
+
+func TestCompareIgnoreCase(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tt.Equal(0, CompareIgnoreCase("ZlsGo", "zlsgo"))
+	tt.Equal(-1, CompareIgnoreCase("apple", "Banana"))
+	tt.Equal(1, CompareIgnoreCase("Banana", "apple"))
+	tt.Equal(-1, CompareIgnoreCase("zls", "ZLSGO"))
+	tt.Equal(1, CompareIgnoreCase("zlsgo", "ZLS"))
+	tt.Equal(0, CompareIgnoreCase("", ""))
+}
+
+func TestCompareNatural(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tt.Equal(-1, CompareNatural("file2", "file10"))
+	tt.Equal(1, CompareNatural("file10", "file2"))
+	tt.Equal(0, CompareNatural("file10", "file10"))
+	tt.Equal(-1, CompareNatural("a1b2", "a1b10"))
+	tt.Equal(-1, CompareNatural("v1.2.9", "v1.10.0"))
+	tt.Equal(-1, CompareNatural("file", "file1"))
+	tt.Equal(-1, CompareNatural("a", "b"))
+	tt.Equal(-1, CompareNatural("file01", "file1"))
+
+	l := []string{"img12.png", "img10.png", "IMG3.png", "img2.png", "img1.png", "img02.png"}
+	sort.SliceStable(l, func(i, j int) bool {
+		return CompareNatural(l[i], l[j]) < 0
+	})
+	tt.Equal([]string{"IMG3.png", "img1.png", "img02.png", "img2.png", "img10.png", "img12.png"}, l)
+}
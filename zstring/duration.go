@@ -0,0 +1,71 @@
+package zstring
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration like time.ParseDuration, additionally supports d (day) and w (week) units
+func ParseDuration(s string) (time.Duration, error) {
+	str := s
+	neg := false
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+
+	if str == "" {
+		return 0, errors.New("invalid duration: " + strconv.Quote(s))
+	}
+
+	var (
+		extra float64
+		rest  strings.Builder
+	)
+	for str != "" {
+		i := 0
+		for ; i < len(str) && (str[i] == '.' || (str[i] >= '0' && str[i] <= '9')); i++ {
+		}
+		j := i
+		for ; j < len(str) && str[j] != '.' && (str[j] < '0' || str[j] > '9'); j++ {
+		}
+
+		unit := str[i:j]
+		if unit == "d" || unit == "w" {
+			n, err := strconv.ParseFloat(str[:i], 64)
+			if err != nil {
+				return 0, errors.New("invalid duration: " + strconv.Quote(s))
+			}
+			if unit == "w" {
+				n *= 7
+			}
+			extra += n * float64(24*time.Hour)
+		} else {
+			rest.WriteString(str[:j])
+		}
+		str = str[j:]
+	}
+
+	if extra >= math.MaxInt64 {
+		return 0, errors.New("invalid duration: " + strconv.Quote(s) + " out of range")
+	}
+	d := time.Duration(extra)
+	if rest.Len() > 0 {
+		v, err := time.ParseDuration(rest.String())
+		if err != nil {
+			return 0, errors.New("invalid duration: " + strconv.Quote(s))
+		}
+		if v > math.MaxInt64-d {
+			return 0, errors.New("invalid duration: " + strconv.Quote(s) + " out of range")
+		}
+		d += v
+	}
+
+	if neg {
+		return -d, nil
+	}
+	return d, nil
+}
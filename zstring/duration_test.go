@@ -0,0 +1,36 @@
+package zstring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestParseDuration(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tests := map[string]time.Duration{
+		"0":        0,
+		"7d":       168 * time.Hour,
+		"2w":       14 * 24 * time.Hour,
+		"1w2d":     9 * 24 * time.Hour,
+		"1.5d":     36 * time.Hour,
+		"1d12h30m": 36*time.Hour + 30*time.Minute,
+		"-1d":      -24 * time.Hour,
+		"1h30m":    90 * time.Minute,
+		"300ms":    300 * time.Millisecond,
+		"1.5h":     90 * time.Minute,
+		"106750d":  106750 * 24 * time.Hour,
+	}
+	for s, expected := range tests {
+		d, err := ParseDuration(s)
+		tt.NoError(err)
+		tt.Equal(expected, d)
+	}
+
+	for _, s := range []string{"", "-", "d", "abc", "1x", "1.2.3d", "10", "99999999999d", "-99999999999w", "106752d", "106751d24h"} {
+		_, err := ParseDuration(s)
+		tt.EqualTrue(err != nil)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/gob"
 	"fmt"
+	"html"
 	"io/ioutil"
 	"path/filepath"
 	"strings"
@@ -76,3 +77,21 @@ func Img2Base64(path string) (string, error) {
 	imgType = strings.ToLower(imgType)
 	return fmt.Sprintf("data:image/%s;base64,%s", imgType, Bytes2String(Base64Encode(imgBuffer))), nil
 }
+
+var htmlEntitiesReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&#39;",
+)
+
+// EncodeHTMLEntities encode the five mandatory html entities
+func EncodeHTMLEntities(s string) string {
+	return htmlEntitiesReplacer.Replace(s)
+}
+
+// DecodeHTMLEntities decode named and numeric html entities, unknown entities are left unchanged
+func DecodeHTMLEntities(s string) string {
+	return html.UnescapeString(s)
+}
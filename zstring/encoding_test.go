@@ -63,3 +63,17 @@ func TestImg2Base64(t *testing.T) {
 		}
 	}
 }
+
+func TestHTMLEntities(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	raw := `<a href="x">Tom & 'Jerry'</a>`
+	encoded := "&lt;a href=&quot;x&quot;&gt;Tom &amp; &#39;Jerry&#39;&lt;/a&gt;"
+
+	tt.Equal(encoded, zstring.EncodeHTMLEntities(raw))
+	tt.Equal(raw, zstring.DecodeHTMLEntities(encoded))
+	tt.Equal("&&&'", zstring.DecodeHTMLEntities("&#38;&#x26;&amp;&apos;"))
+	tt.Equal("&unknown; &#xZZ;", zstring.DecodeHTMLEntities("&unknown; &#xZZ;"))
+	tt.Equal("© €", zstring.DecodeHTMLEntities("&copy; &#8364;"))
+	tt.Equal("", zstring.EncodeHTMLEntities(""))
+	tt.Equal("", zstring.DecodeHTMLEntities(""))
+}
@@ -0,0 +1,202 @@
+package zstring
+
+// soundexCodes A-Z soundex digits, '0' means vowel like letter
+const soundexCodes = "01230120022455012623010202"
+
+// Soundex returns the 4-character American Soundex code of the string
+func Soundex(s string) string {
+	res := make([]byte, 0, 4)
+	var last byte
+	for i := 0; i < len(s) && len(res) < 4; i++ {
+		c := toUpperLetter(s[i])
+		if c == 0 {
+			continue
+		}
+
+		code := soundexCodes[c-'A']
+		if len(res) == 0 {
+			res = append(res, c)
+			last = code
+			continue
+		}
+
+		switch {
+		case c == 'H' || c == 'W':
+		case code == '0':
+			last = code
+		case code != last:
+			res = append(res, code)
+			last = code
+		}
+	}
+
+	if len(res) == 0 {
+		return ""
+	}
+
+	for len(res) < 4 {
+		res = append(res, '0')
+	}
+	return string(res)
+}
+
+// Metaphone returns the Metaphone phonetic key of the string
+func Metaphone(s string) string {
+	w := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if c := toUpperLetter(s[i]); c != 0 {
+			w = append(w, c)
+		}
+	}
+
+	if len(w) < 2 {
+		return string(w)
+	}
+
+	switch string(w[:2]) {
+	case "AE", "GN", "KN", "PN", "WR":
+		w = w[1:]
+	case "WH":
+		w = append(w[:1], w[2:]...)
+	default:
+		if w[0] == 'X' {
+			w[0] = 'S'
+		}
+	}
+
+	n := len(w)
+	at := func(i int) byte {
+		if i < 0 || i >= n {
+			return 0
+		}
+		return w[i]
+	}
+
+	res := make([]byte, 0, n)
+	for i := 0; i < n; i++ {
+		c := w[i]
+		if c != 'C' && i > 0 && w[i-1] == c {
+			continue
+		}
+
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				res = append(res, c)
+			}
+		case 'B':
+			if !(i == n-1 && at(i-1) == 'M') {
+				res = append(res, c)
+			}
+		case 'C':
+			switch {
+			case at(i-1) == 'S' && isFrontVowel(at(i+1)):
+			case at(i+1) == 'I' && at(i+2) == 'A':
+				res = append(res, 'X')
+			case isFrontVowel(at(i + 1)):
+				res = append(res, 'S')
+			case at(i+1) == 'H':
+				if at(i-1) == 'S' {
+					res = append(res, 'K')
+				} else {
+					res = append(res, 'X')
+				}
+				i++
+			default:
+				res = append(res, 'K')
+			}
+		case 'D':
+			if at(i+1) == 'G' && isFrontVowel(at(i+2)) {
+				res = append(res, 'J')
+				i += 2
+			} else {
+				res = append(res, 'T')
+			}
+		case 'G':
+			switch {
+			case at(i+1) == 'H' && i+2 < n && !isVowel(at(i+2)):
+			case at(i+1) == 'N' && (i+2 == n || (i+4 == n && at(i+2) == 'E' && at(i+3) == 'D')):
+			case isFrontVowel(at(i + 1)):
+				res = append(res, 'J')
+			default:
+				res = append(res, 'K')
+			}
+		case 'H':
+			switch at(i - 1) {
+			case 'C', 'S', 'P', 'T', 'G':
+			default:
+				if isVowel(at(i + 1)) {
+					res = append(res, c)
+				}
+			}
+		case 'K':
+			if at(i-1) != 'C' {
+				res = append(res, c)
+			}
+		case 'P':
+			if at(i+1) == 'H' {
+				res = append(res, 'F')
+			} else {
+				res = append(res, c)
+			}
+		case 'Q':
+			res = append(res, 'K')
+		case 'S':
+			if at(i+1) == 'H' || (at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A')) {
+				res = append(res, 'X')
+			} else {
+				res = append(res, c)
+			}
+		case 'T':
+			switch {
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				res = append(res, 'X')
+			case at(i+1) == 'H':
+				res = append(res, '0')
+			case at(i+1) == 'C' && at(i+2) == 'H':
+			default:
+				res = append(res, c)
+			}
+		case 'V':
+			res = append(res, 'F')
+		case 'W', 'Y':
+			if isVowel(at(i + 1)) {
+				res = append(res, c)
+			}
+		case 'X':
+			res = append(res, 'K', 'S')
+		case 'Z':
+			res = append(res, 'S')
+		default:
+			res = append(res, c)
+		}
+	}
+
+	return string(res)
+}
+
+func toUpperLetter(c byte) byte {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return c - 'a' + 'A'
+	case c >= 'A' && c <= 'Z':
+		return c
+	}
+	return 0
+}
+
+func isVowel(c byte) bool {
+	switch c {
+	case 'A', 'E', 'I', 'O', 'U':
+		return true
+	}
+	return false
+}
+
+func isFrontVowel(c byte) bool {
+	switch c {
+	case 'E', 'I', 'Y':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package zstring
+
+import (
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestSoundex(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tests := map[string]string{
+		"Robert":   "R163",
+		"Rupert":   "R163",
+		"Rubin":    "R150",
+		"Ashcraft": "A261",
+		"Ashcroft": "A261",
+		"Tymczak":  "T522",
+		"Pfister":  "P236",
+		"Honeyman": "H555",
+		"Lee":      "L000",
+		"o'Hara":   "O600",
+		"":         "",
+		"123":      "",
+	}
+	for s, expected := range tests {
+		tt.Equal(expected, Soundex(s))
+	}
+}
+
+func TestMetaphone(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tests := map[string]string{
+		"Thumb":    "0M",
+		"Knight":   "NT",
+		"Wright":   "RT",
+		"Phone":    "FN",
+		"Science":  "SNS",
+		"Smith":    "SM0",
+		"Xavier":   "SFR",
+		"Aubrey":   "ABR",
+		"White":    "WT",
+		"Nation":   "NXN",
+		"Dodge":    "TJ",
+		"Accident": "AKSTNT",
+		"Merry":    "MR",
+		"A":        "A",
+		"":         "",
+	}
+	for s, expected := range tests {
+		tt.Equal(expected, Metaphone(s))
+	}
+}
@@ -1,21 +1,26 @@
 package zstring
 
 import (
+	"container/list"
 	"regexp"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type regexMapStruct struct {
-	Value *regexp.Regexp
-	Time  int64
-	sync.RWMutex
+	Value   *regexp.Regexp
+	pattern string
+	Time    int64
 }
 
 var (
-	l                 sync.RWMutex
-	regexCache             = map[string]*regexMapStruct{}
-	regexCacheTimeout uint = 1800
+	l                 sync.Mutex
+	regexCache              = map[string]*list.Element{}
+	regexList               = list.New()
+	regexCacheTimeout uint  = 1800
+	regexCacheMax     int64 = 1000
 )
 
 func init() {
@@ -27,6 +32,34 @@ func init() {
 	}()
 }
 
+// SetRegexpCacheSize set the maximum number of cached regexps, least recently used are evicted first,
+// a size of 0 or less disables the cache and every call compiles the pattern again
+func SetRegexpCacheSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	atomic.StoreInt64(&regexCacheMax, int64(size))
+	l.Lock()
+	for int64(regexList.Len()) > int64(size) {
+		evictRegexpCompile()
+	}
+	l.Unlock()
+}
+
+// CompileRegexp compile the regexp and cache it for reuse
+func CompileRegexp(pattern string) (*regexp.Regexp, error) {
+	return getRegexpCompile(pattern)
+}
+
+// MustCompileRegexp like CompileRegexp but panics if the pattern cannot be parsed
+func MustCompileRegexp(pattern string) *regexp.Regexp {
+	r, err := getRegexpCompile(pattern)
+	if err != nil {
+		panic(`regexp: Compile(` + strconv.Quote(pattern) + `): ` + err.Error())
+	}
+	return r
+}
+
 // RegexMatch check for match
 func RegexMatch(pattern string, str string) bool {
 	if r, err := getRegexpCompile(pattern); err == nil {
@@ -94,37 +127,58 @@ func RegexSplit(pattern string, str string) ([]string, error) {
 }
 
 func clearRegexpCompile() {
-	newRegexCache := map[string]*regexMapStruct{}
 	l.Lock()
 	defer l.Unlock()
-	if len(regexCache) == 0 {
-		return
-	}
 	now := time.Now().Unix()
-	for k := range regexCache {
-		if uint(now-regexCache[k].Time) <= regexCacheTimeout {
-			newRegexCache[k] = &regexMapStruct{Value: regexCache[k].Value, Time: now}
+	for e := regexList.Front(); e != nil; {
+		next := e.Next()
+		data := e.Value.(*regexMapStruct)
+		if uint(now-data.Time) <= regexCacheTimeout {
+			data.Time = now
+		} else {
+			regexList.Remove(e)
+			delete(regexCache, data.pattern)
 		}
+		e = next
 	}
-	regexCache = newRegexCache
 }
 
 func getRegexpCompile(pattern string) (r *regexp.Regexp, err error) {
-	l.RLock()
-	var data *regexMapStruct
-	var ok bool
-	data, ok = regexCache[pattern]
-	l.RUnlock()
-	if ok {
-		r = data.Value
+	l.Lock()
+	if e, ok := regexCache[pattern]; ok {
+		regexList.MoveToFront(e)
+		r = e.Value.(*regexMapStruct).Value
+		l.Unlock()
 		return
 	}
+	l.Unlock()
+
 	r, err = regexp.Compile(pattern)
 	if err != nil {
 		return
 	}
+	max := atomic.LoadInt64(&regexCacheMax)
+	if max <= 0 {
+		return
+	}
+
 	l.Lock()
-	regexCache[pattern] = &regexMapStruct{Value: r, Time: time.Now().Unix()}
+	if e, ok := regexCache[pattern]; ok {
+		regexList.MoveToFront(e)
+		r = e.Value.(*regexMapStruct).Value
+	} else {
+		for int64(regexList.Len()) >= max {
+			evictRegexpCompile()
+		}
+		regexCache[pattern] = regexList.PushFront(&regexMapStruct{Value: r, pattern: pattern, Time: time.Now().Unix()})
+	}
 	l.Unlock()
 	return
 }
+
+func evictRegexpCompile() {
+	if e := regexList.Back(); e != nil {
+		regexList.Remove(e)
+		delete(regexCache, e.Value.(*regexMapStruct).pattern)
+	}
+}
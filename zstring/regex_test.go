@@ -2,6 +2,8 @@ package zstring
 
 import (
 	"regexp"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/sohaha/zlsgo"
@@ -50,8 +52,9 @@ func TestRegex(T *testing.T) {
 
 	clearRegexpCompile()
 
-	regexCache = map[string]*regexMapStruct{}
+	SetRegexpCacheSize(0)
 	clearRegexpCompile()
+	SetRegexpCacheSize(1000)
 }
 
 func BenchmarkRegex1(b *testing.B) {
@@ -73,3 +76,83 @@ func BenchmarkRegex3(b *testing.B) {
 		r.Match(String2Bytes("这就是我啊!"))
 	}
 }
+
+func TestCompileRegexp(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	r1, err := CompileRegexp(`^\d+$`)
+	tt.NoError(err)
+	r2, err := CompileRegexp(`^\d+$`)
+	tt.NoError(err)
+	tt.EqualTrue(r1 == r2)
+
+	r3 := MustCompileRegexp(`^\w+$`)
+	tt.EqualTrue(r1 != r3)
+	tt.EqualTrue(r3.MatchString("abc"))
+
+	_, err = CompileRegexp(`(`)
+	tt.EqualTrue(err != nil)
+
+	func() {
+		defer func() {
+			tt.EqualTrue(recover() != nil)
+		}()
+		MustCompileRegexp(`(`)
+	}()
+}
+
+func TestRegexpCacheSize(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	defer SetRegexpCacheSize(1000)
+
+	SetRegexpCacheSize(0)
+	SetRegexpCacheSize(2)
+
+	a := MustCompileRegexp("a")
+	b := MustCompileRegexp("b")
+	tt.EqualTrue(a == MustCompileRegexp("a"))
+	MustCompileRegexp("c")
+
+	l.Lock()
+	_, hasA := regexCache["a"]
+	_, hasB := regexCache["b"]
+	size := len(regexCache)
+	l.Unlock()
+	tt.Equal(2, size)
+	tt.Equal(2, regexList.Len())
+	tt.EqualTrue(hasA)
+	tt.EqualTrue(!hasB)
+	tt.EqualTrue(b != MustCompileRegexp("b"))
+
+	SetRegexpCacheSize(1)
+	l.Lock()
+	size = len(regexCache)
+	l.Unlock()
+	tt.Equal(1, size)
+
+	SetRegexpCacheSize(0)
+	c := MustCompileRegexp("c")
+	tt.EqualTrue(c != MustCompileRegexp("c"))
+	l.Lock()
+	size = len(regexCache)
+	l.Unlock()
+	tt.Equal(0, size)
+}
+
+func TestCompileRegexpConcurrent(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r, err := CompileRegexp(`^` + strconv.Itoa(j%10) + `$`)
+				tt.NoError(err)
+				tt.EqualTrue(r.MatchString(strconv.Itoa(j % 10)))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,92 @@
+package zstring
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	sizeUnits = map[string]float64{
+		"":    1,
+		"B":   1,
+		"K":   1e3,
+		"KB":  1e3,
+		"M":   1e6,
+		"MB":  1e6,
+		"G":   1e9,
+		"GB":  1e9,
+		"T":   1e12,
+		"TB":  1e12,
+		"P":   1e15,
+		"PB":  1e15,
+		"E":   1e18,
+		"EB":  1e18,
+		"KIB": 1 << 10,
+		"MIB": 1 << 20,
+		"GIB": 1 << 30,
+		"TIB": 1 << 40,
+		"PIB": 1 << 50,
+		"EIB": 1 << 60,
+	}
+	siSizeSuffixes     = []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+	binarySizeSuffixes = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+)
+
+// ParseSize parse human-readable byte size, such as 10KB, 1.5MB, 2GiB
+func ParseSize(s string) (int64, error) {
+	str := strings.TrimSpace(s)
+	i := 0
+	for ; i < len(str); i++ {
+		if (str[i] < '0' || str[i] > '9') && str[i] != '.' {
+			break
+		}
+	}
+
+	if i == 0 {
+		return 0, errors.New("invalid size: " + strconv.Quote(s))
+	}
+
+	n, err := strconv.ParseFloat(str[:i], 64)
+	if err != nil {
+		return 0, errors.New("invalid size: " + strconv.Quote(s))
+	}
+
+	unit, ok := sizeUnits[strings.ToUpper(strings.TrimSpace(str[i:]))]
+	if !ok {
+		return 0, errors.New("unknown unit in size: " + strconv.Quote(s))
+	}
+
+	size := n * unit
+	if size >= math.MaxInt64 {
+		return 0, errors.New("size overflow: " + strconv.Quote(s))
+	}
+
+	return int64(size), nil
+}
+
+// FormatSize format byte size to human-readable string, binary uses 1024 based units
+func FormatSize(n int64, binary bool) string {
+	base, suffixes := 1000.0, siSizeSuffixes
+	if binary {
+		base, suffixes = 1024.0, binarySizeSuffixes
+	}
+
+	sign := ""
+	v := float64(n)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+
+	i := 0
+	for ; v >= base && i < len(suffixes)-1; i++ {
+		v /= base
+	}
+
+	if i == 0 {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+
+	return sign + strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64) + " " + suffixes[i]
+}
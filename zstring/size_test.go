@@ -0,0 +1,53 @@
+package zstring
+
+import (
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestParseSize(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tests := map[string]int64{
+		"0":      0,
+		"100":    100,
+		"100B":   100,
+		"1KB":    1000,
+		"1kb":    1000,
+		"1KiB":   1024,
+		"10 KB":  10000,
+		"1.5MB":  1500000,
+		"1.5MiB": 1572864,
+		"2GiB":   2147483648,
+		"1TB":    1000000000000,
+	}
+	for s, expected := range tests {
+		n, err := ParseSize(s)
+		tt.NoError(err)
+		tt.Equal(expected, n)
+	}
+
+	for _, s := range []string{"", "abc", "KB", "1XB", "1..5MB", "-1KB", "100EiB"} {
+		_, err := ParseSize(s)
+		tt.EqualTrue(err != nil)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tt.Equal("0 B", FormatSize(0, false))
+	tt.Equal("0 B", FormatSize(0, true))
+	tt.Equal("999 B", FormatSize(999, false))
+	tt.Equal("1 KB", FormatSize(1000, false))
+	tt.Equal("1000 B", FormatSize(1000, true))
+	tt.Equal("1 KiB", FormatSize(1024, true))
+	tt.Equal("1.5 MB", FormatSize(1500000, false))
+	tt.Equal("1.5 MiB", FormatSize(1572864, true))
+	tt.Equal("2 GiB", FormatSize(2147483648, true))
+	tt.Equal("-1.5 KB", FormatSize(-1500, false))
+
+	n, _ := ParseSize(FormatSize(1572864, true))
+	tt.Equal(int64(1572864), n)
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -52,6 +53,28 @@ func Pad(raw string, length int, padStr string, padType PadType) string {
 	return raw
 }
 
+// PadNumber zero-pad an integer to width like fmt.Sprintf("%0*d", width, n),
+// the sign counts toward the width
+func PadNumber(n int64, width int) string {
+	s := strconv.FormatInt(n, 10)
+	l := width - len(s)
+	if l <= 0 {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(width)
+	if n < 0 {
+		b.WriteByte('-')
+		s = s[1:]
+	}
+	for i := 0; i < l; i++ {
+		b.WriteByte('0')
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
 // Len string length (utf8)
 func Len(str string) int {
 	// strings.Count(str,"")-1
@@ -231,3 +254,138 @@ func TrimSpace(s string) string {
 	}
 	return s
 }
+
+// Lines split s into lines on \n, \r\n and \r, without line terminators
+func Lines(s string) []string {
+	if s == "" {
+		return []string{""}
+	}
+
+	lines := make([]string, 0, strings.Count(s, "\n")+1)
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\n':
+			lines = append(lines, s[start:i])
+			start = i + 1
+		case '\r':
+			lines = append(lines, s[start:i])
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		lines = append(lines, s[start:])
+	}
+	return lines
+}
+
+// Indent add prefix to the beginning of every non-blank line in s
+func Indent(s, prefix string) string {
+	if prefix == "" || s == "" {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) + len(prefix)*(strings.Count(s, "\n")+1))
+	for _, line := range strings.SplitAfter(s, "\n") {
+		if strings.TrimSpace(line) != "" {
+			b.WriteString(prefix)
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// Dedent remove common leading whitespace from every non-blank line in s,
+// blank lines are normalized to empty lines
+func Dedent(s string) string {
+	lines := strings.SplitAfter(s, "\n")
+	margin, first := "", true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			margin, first = indent, false
+			continue
+		}
+		i := 0
+		for i < len(margin) && i < len(indent) && margin[i] == indent[i] {
+			i++
+		}
+		margin = margin[:i]
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if strings.HasSuffix(line, "\n") {
+				b.WriteByte('\n')
+			}
+			continue
+		}
+		b.WriteString(line[len(margin):])
+	}
+	return b.String()
+}
+
+type runeSet struct {
+	other map[rune]struct{}
+	ascii [4]uint64
+}
+
+func newRuneSet(chars string) *runeSet {
+	set := &runeSet{}
+	for _, r := range chars {
+		if r < utf8.RuneSelf {
+			set.ascii[r>>6] |= 1 << (uint(r) & 63)
+			continue
+		}
+		if set.other == nil {
+			set.other = make(map[rune]struct{})
+		}
+		set.other[r] = struct{}{}
+	}
+	return set
+}
+
+func (s *runeSet) has(r rune) bool {
+	if r < utf8.RuneSelf {
+		return s.ascii[r>>6]&(1<<(uint(r)&63)) != 0
+	}
+	_, ok := s.other[r]
+	return ok
+}
+
+// ContainsRune reports whether any rune of chars is in s
+func ContainsRune(s string, chars string) bool {
+	if chars == "" || s == "" {
+		return false
+	}
+	set := newRuneSet(chars)
+	for _, r := range s {
+		if set.has(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsAllRunes reports whether every rune of chars is in s
+func ContainsAllRunes(s string, chars string) bool {
+	if chars == "" {
+		return true
+	}
+	set := newRuneSet(s)
+	for _, r := range chars {
+		if !set.has(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,8 @@
 package zstring
 
 import (
+	"fmt"
+	"math"
 	"math/rand"
 	"strings"
 	"testing"
@@ -55,6 +57,17 @@ func TestPad(T *testing.T) {
 	t.Equal("000我的长度是二十,不够两边补零000", Pad(s4, 20, "0", PadSides))
 }
 
+func TestPadNumber(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tt.Equal("00042", PadNumber(42, 5))
+	tt.Equal("-007", PadNumber(-7, 4))
+	tt.Equal("12345", PadNumber(12345, 5))
+	tt.Equal("1234567", PadNumber(1234567, 3))
+	tt.Equal("-123", PadNumber(-123, 2))
+	tt.Equal("0", PadNumber(0, 0))
+	tt.Equal(fmt.Sprintf("%020d", int64(math.MinInt64)), PadNumber(math.MinInt64, 20))
+}
+
 func TestFirst(T *testing.T) {
 	t := zlsgo.NewTest(T)
 	str := "myName"
@@ -136,6 +149,15 @@ func BenchmarkStr(b *testing.B) {
 	}
 }
 
+func TestBytesString(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	s := "我是中国人 zlsgo"
+	tt.Equal([]byte(s), Bytes(s))
+	tt.Equal(s, String([]byte(s)))
+	tt.Equal(0, len(Bytes("")))
+	tt.Equal("", String(nil))
+}
+
 func BenchmarkStrBuffer(b *testing.B) {
 	s := Buffer()
 	for i := 0; i < b.N; i++ {
@@ -171,6 +193,56 @@ func getRandomString(l int) string {
 	return string(result)
 }
 
+func TestLines(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tt.Equal([]string{""}, Lines(""))
+	tt.Equal([]string{"a", "b", "c"}, Lines("a\nb\nc"))
+	tt.Equal([]string{"a", "b", "c"}, Lines("a\r\nb\r\nc"))
+	tt.Equal([]string{"a", "b", "c"}, Lines("a\rb\rc"))
+	tt.Equal([]string{"a", "b", "c", "d"}, Lines("a\nb\r\nc\rd"))
+	tt.Equal([]string{"a", "b"}, Lines("a\nb\n"))
+	tt.Equal([]string{"a", "b"}, Lines("a\r\nb\r\n"))
+	tt.Equal([]string{"a", "", "b"}, Lines("a\n\nb"))
+	tt.Equal([]string{""}, Lines("\n"))
+}
+
+func TestIndent(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tt.Equal("  a\n  b\n", Indent("a\nb\n", "  "))
+	tt.Equal("> a\n\n> b", Indent("a\n\nb", "> "))
+	tt.Equal("  a\r\n  b", Indent("a\r\nb", "  "))
+	tt.Equal("", Indent("", "  "))
+}
+
+func TestDedent(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tt.Equal("a\nb\n", Dedent("    a\n    b\n"))
+	tt.Equal("a\n  b\nc", Dedent("  a\n    b\n  c"))
+	tt.Equal("a\n\nb\n", Dedent("  a\n\n  b\n"))
+	tt.Equal("a\n\nb", Dedent("    a\n  \n    b"))
+	tt.Equal("\ta\n b", Dedent(" \ta\n  b"))
+	tt.Equal("a\nb", Dedent("a\nb"))
+	tt.Equal(Dedent(Indent("a\n  b\n", "\t")), "a\n  b\n")
+}
+
+func TestContainsRune(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	tt.EqualTrue(ContainsRune("hello", "o"))
+	tt.EqualTrue(ContainsRune("hello", "xyzl"))
+	tt.EqualTrue(!ContainsRune("hello", "xyz"))
+	tt.EqualTrue(!ContainsRune("hello", ""))
+	tt.EqualTrue(!ContainsRune("", "a"))
+	tt.EqualTrue(ContainsRune("你好世界", "界x"))
+	tt.EqualTrue(!ContainsRune("你好世界", "天地"))
+
+	tt.EqualTrue(ContainsAllRunes("hello", "leh"))
+	tt.EqualTrue(!ContainsAllRunes("hello", "hex"))
+	tt.EqualTrue(ContainsAllRunes("hello", ""))
+	tt.EqualTrue(ContainsAllRunes("", ""))
+	tt.EqualTrue(ContainsAllRunes("你好 world", "好w"))
+	tt.EqualTrue(!ContainsAllRunes("你好 world", "好天"))
+}
+
 func BenchmarkBuffer1(b *testing.B) {
 	bb := Buffer()
 	str := getRandomString(99999)
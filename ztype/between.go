@@ -0,0 +1,16 @@
+//go:build go1.18
+// +build go1.18
+
+package ztype
+
+import "golang.org/x/exp/constraints"
+
+// Between reports whether v is within [lo, hi]
+func Between[T constraints.Ordered](v, lo, hi T) bool {
+	return v >= lo && v <= hi
+}
+
+// BetweenExclusive reports whether v is within (lo, hi)
+func BetweenExclusive[T constraints.Ordered](v, lo, hi T) bool {
+	return v > lo && v < hi
+}
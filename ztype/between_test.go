@@ -0,0 +1,29 @@
+//go:build go1.18
+// +build go1.18
+
+package ztype_test
+
+import (
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+	"github.com/sohaha/zlsgo/ztype"
+)
+
+func TestBetween(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tt.EqualTrue(ztype.Between(1, 1, 10))
+	tt.EqualTrue(ztype.Between(10, 1, 10))
+	tt.EqualTrue(ztype.Between(5, 1, 10))
+	tt.EqualTrue(!ztype.Between(0, 1, 10))
+	tt.EqualTrue(!ztype.Between(11, 1, 10))
+	tt.EqualTrue(ztype.Between(1.5, 1.0, 2.0))
+	tt.EqualTrue(ztype.Between("b", "a", "c"))
+
+	tt.EqualTrue(!ztype.BetweenExclusive(1, 1, 10))
+	tt.EqualTrue(!ztype.BetweenExclusive(10, 1, 10))
+	tt.EqualTrue(ztype.BetweenExclusive(5, 1, 10))
+	tt.EqualTrue(!ztype.BetweenExclusive(0, 1, 10))
+	tt.EqualTrue(!ztype.BetweenExclusive(11, 1, 10))
+}
@@ -0,0 +1,60 @@
+//go:build go1.18
+// +build go1.18
+
+package ztype
+
+import (
+	"fmt"
+	"time"
+)
+
+// Coerce convert v to T, basic types use the matching To* converter
+// and other types fall back to To
+func Coerce[T any](v interface{}) (T, error) {
+	var res T
+	var r interface{}
+	switch any(res).(type) {
+	case string:
+		r = ToString(v)
+	case []byte:
+		r = ToBytes(v)
+	case bool:
+		r = ToBool(v)
+	case int:
+		r = ToInt(v)
+	case int8:
+		r = ToInt8(v)
+	case int16:
+		r = ToInt16(v)
+	case int32:
+		r = ToInt32(v)
+	case int64:
+		r = ToInt64(v)
+	case uint:
+		r = ToUint(v)
+	case uint8:
+		r = ToUint8(v)
+	case uint16:
+		r = ToUint16(v)
+	case uint32:
+		r = ToUint32(v)
+	case uint64:
+		r = ToUint64(v)
+	case float32:
+		r = ToFloat32(v)
+	case float64:
+		r = ToFloat64(v)
+	case time.Time:
+		t, err := ToTime(v)
+		if err != nil {
+			return res, err
+		}
+		r = t
+	default:
+		if err := To(v, &res); err != nil {
+			return res, fmt.Errorf("cannot coerce %T to %T: %w", v, res, err)
+		}
+		return res, nil
+	}
+	return r.(T), nil
+}
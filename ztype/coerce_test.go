@@ -0,0 +1,40 @@
+//go:build go1.18
+// +build go1.18
+
+package ztype_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+	"github.com/sohaha/zlsgo/ztype"
+)
+
+func TestCoerce(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	i, err := ztype.Coerce[int]("12")
+	tt.NoError(err)
+	tt.Equal(12, i)
+
+	s, err := ztype.Coerce[string](12.5)
+	tt.NoError(err)
+	tt.Equal("12.5", s)
+
+	b, err := ztype.Coerce[bool]("true")
+	tt.NoError(err)
+	tt.EqualTrue(b)
+
+	u, err := ztype.Coerce[uint8](300)
+	tt.NoError(err)
+	tt.Equal(ztype.ToUint8(300), u)
+
+	m, err := ztype.Coerce[map[string]int](map[string]interface{}{"a": "1"})
+	tt.NoError(err)
+	tt.Equal(map[string]int{"a": 1}, m)
+
+	_, err = ztype.Coerce[chan int](1)
+	tt.EqualTrue(err != nil)
+	tt.EqualTrue(strings.Contains(err.Error(), "cannot coerce int to chan int"))
+}
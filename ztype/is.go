@@ -149,3 +149,22 @@ func IsEmpty(value interface{}) bool {
 	}
 	return false
 }
+
+// IsNil Is nil, including an interface holding a nil pointer, slice, map, chan or func
+func IsNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Chan,
+		reflect.Func,
+		reflect.Interface,
+		reflect.Map,
+		reflect.Ptr,
+		reflect.Slice,
+		reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
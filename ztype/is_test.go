@@ -143,3 +143,28 @@ func TestIsEmpty(T *testing.T) {
 	var s interface{}
 	t.EqualTrue(IsEmpty(s))
 }
+
+func TestIsNil(T *testing.T) {
+	t := zlsgo.NewTest(T)
+	var (
+		i  interface{}
+		p  *int
+		s  []int
+		m  map[string]int
+		ch chan int
+		fn func()
+		n  = 1
+	)
+	t.EqualTrue(IsNil(nil))
+	t.EqualTrue(IsNil(i))
+	t.EqualTrue(IsNil(p))
+	t.EqualTrue(IsNil(interface{}(p)))
+	t.EqualTrue(IsNil(s))
+	t.EqualTrue(IsNil(m))
+	t.EqualTrue(IsNil(ch))
+	t.EqualTrue(IsNil(fn))
+	t.EqualTrue(!IsNil(&n))
+	t.EqualTrue(!IsNil([]int{}))
+	t.EqualTrue(!IsNil(0))
+	t.EqualTrue(!IsNil(""))
+}
@@ -0,0 +1,91 @@
+//go:build go1.18
+// +build go1.18
+
+package ztype
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Result holds either a value or an error
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful result
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a failed result
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// IsOk returns true if the result has no error
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Unwrap returns the value, panics if the result is an error
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r.value
+}
+
+// UnwrapErr returns the error of the result
+func (r Result[T]) UnwrapErr() error {
+	return r.err
+}
+
+// Map transforms the value if the result is ok
+func (r Result[T]) Map(fn func(T) T) Result[T] {
+	if r.err != nil {
+		return r
+	}
+	return Ok(fn(r.value))
+}
+
+// FlatMap chains another fallible operation if the result is ok
+func (r Result[T]) FlatMap(fn func(T) Result[T]) Result[T] {
+	if r.err != nil {
+		return r
+	}
+	return fn(r.value)
+}
+
+type resultJSON[T any] struct {
+	Value *T      `json:"value,omitempty"`
+	Error *string `json:"error,omitempty"`
+}
+
+func (r Result[T]) MarshalJSON() ([]byte, error) {
+	if r.err != nil {
+		e := r.err.Error()
+		return json.Marshal(resultJSON[T]{Error: &e})
+	}
+	return json.Marshal(resultJSON[T]{Value: &r.value})
+}
+
+func (r *Result[T]) UnmarshalJSON(data []byte) error {
+	var v resultJSON[T]
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Error != nil {
+		*r = Err[T](errors.New(*v.Error))
+		return nil
+	}
+
+	var value T
+	if v.Value != nil {
+		value = *v.Value
+	}
+	*r = Ok(value)
+	return nil
+}
@@ -0,0 +1,67 @@
+//go:build go1.18
+// +build go1.18
+
+package ztype_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+	"github.com/sohaha/zlsgo/ztype"
+)
+
+func TestResult(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	r := ztype.Ok(1)
+	tt.EqualTrue(r.IsOk())
+	tt.Equal(1, r.Unwrap())
+	tt.EqualNil(r.UnwrapErr())
+
+	e := errors.New("fail")
+	r = ztype.Err[int](e)
+	tt.EqualTrue(!r.IsOk())
+	tt.Equal(e, r.UnwrapErr())
+
+	func() {
+		defer func() {
+			tt.Equal(e, recover())
+		}()
+		r.Unwrap()
+	}()
+
+	double := func(v int) int { return v * 2 }
+	tt.Equal(4, ztype.Ok(2).Map(double).Unwrap())
+	tt.Equal(e, ztype.Err[int](e).Map(double).UnwrapErr())
+
+	positive := func(v int) ztype.Result[int] {
+		if v < 0 {
+			return ztype.Err[int](errors.New("negative"))
+		}
+		return ztype.Ok(v)
+	}
+	tt.Equal(6, ztype.Ok(3).FlatMap(positive).Map(double).FlatMap(positive).Unwrap())
+	tt.Equal("negative", ztype.Ok(-1).FlatMap(positive).Map(double).UnwrapErr().Error())
+}
+
+func TestResultJSON(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	j, err := json.Marshal(ztype.Ok("zls"))
+	tt.NoError(err)
+	tt.Equal(`{"value":"zls"}`, string(j))
+
+	var r ztype.Result[string]
+	tt.NoError(json.Unmarshal(j, &r))
+	tt.Equal("zls", r.Unwrap())
+
+	j, err = json.Marshal(ztype.Err[string](errors.New("fail")))
+	tt.NoError(err)
+	tt.Equal(`{"error":"fail"}`, string(j))
+
+	tt.NoError(json.Unmarshal(j, &r))
+	tt.EqualTrue(!r.IsOk())
+	tt.Equal("fail", r.UnwrapErr().Error())
+}
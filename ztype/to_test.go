@@ -313,3 +313,30 @@ func TestToStruct(t *testing.T) {
 	tt.Equal(2, len(d.F))
 	t.Log(d)
 }
+
+func TestToStructTagPriority(t *testing.T) {
+	tt := zls.NewTest(t)
+	v := map[string]interface{}{
+		"json_name": "json",
+		"z_name":    "z",
+		"Plain":     "plain",
+	}
+
+	var onlyJSON struct {
+		Name string `json:"json_name"`
+	}
+	tt.NoError(ztype.ToStruct(v, &onlyJSON))
+	tt.Equal("json", onlyJSON.Name)
+
+	var both struct {
+		Name string `z:"z_name" json:"json_name"`
+	}
+	tt.NoError(ztype.ToStruct(v, &both))
+	tt.Equal("z", both.Name)
+
+	var none struct {
+		Plain string
+	}
+	tt.NoError(ztype.ToStruct(v, &none))
+	tt.Equal("plain", none.Plain)
+}
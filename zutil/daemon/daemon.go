@@ -4,6 +4,8 @@ package daemon
 import (
 	"context"
 	"errors"
+	"io"
+	"time"
 )
 
 const (
@@ -16,6 +18,10 @@ const (
 	optionSessionCreate        = "SessionCreate"
 	optionSessionCreateDefault = false
 	optionRunWait              = "RunWait"
+	optionInteractive          = "Interactive"
+	optionEventLog             = "EventLog"
+	stderrToStdout             = "STDOUT"
+	defaultRestartTimeout      = 30 * time.Second
 )
 
 type (
@@ -30,6 +36,11 @@ type (
 		Status() string
 		String() string
 	}
+	// Validator implemented by the services returned from New,
+	// checks the configuration without touching the service manager
+	Validator interface {
+		Validate() error
+	}
 	Iface interface {
 		Start(s ServiceIface) error
 		Stop(s ServiceIface) error
@@ -50,8 +61,20 @@ type (
 		Executable  string
 		WorkingDir  string
 		RootDir     string
-		Arguments   []string
-		Context     context.Context
+		// Stdout file path that the service stdout is written to
+		Stdout string
+		// Stderr file path that the service stderr is written to, "STDOUT" merges it into Stdout
+		Stderr    string
+		Arguments []string
+		Context   context.Context
+		// RestartTimeout bounds Restart, default 30s: on systemd it limits the blocking systemctl restart,
+		// on darwin and windows it limits the wait for the service to stop before it is started again,
+		// ErrRestartTimeout is returned when it passes; rc.d on freebsd ignores it
+		RestartTimeout time.Duration
+		// OnReload called on SIGHUP while the service runs (linux), an error aborts the reload
+		OnReload func() error
+		// Logger receives the install, uninstall, start, stop and restart events, default os.Stderr
+		Logger io.Writer
 	}
 )
 
@@ -62,6 +85,7 @@ var (
 	ErrNoServiceSystemDetected = errors.New("no service system detected")
 	ErrNotAnRootUser           = errors.New("need to execute with sudo permission")
 	ErrNotAnAdministrator      = errors.New("please operate with administrator rights")
+	ErrRestartTimeout          = errors.New("timed out waiting for service to stop")
 )
 
 // New creates a new service based on a service interface and configuration
@@ -72,7 +96,11 @@ func New(i Iface, c *Config) (ServiceIface, error) {
 	if system == nil {
 		return nil, ErrNoServiceSystemDetected
 	}
-	return system.New(i, c)
+	s, err := system.New(i, c)
+	if err != nil {
+		return nil, err
+	}
+	return &loggedService{ServiceIface: s, c: c}, nil
 }
 
 func newSystem() SystemIface {
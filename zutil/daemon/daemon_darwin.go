@@ -138,15 +138,20 @@ func (s *darwinLaunchdService) Install() error {
 	}
 
 	path := s.execPath()
+	stdoutPath, stderrPath := s.outputPaths()
 	to := &struct {
 		*Config
-		Path string
+		Path       string
+		StdoutPath string
+		StderrPath string
 
 		KeepAlive, RunAtLoad bool
 		SessionCreate        bool
 	}{
 		Config:        s.Config,
 		Path:          path,
+		StdoutPath:    stdoutPath,
+		StderrPath:    stderrPath,
 		KeepAlive:     keepAlive,
 		RunAtLoad:     load,
 		SessionCreate: sessionCreate,
@@ -214,12 +219,7 @@ func (s *darwinLaunchdService) Status() string {
 }
 
 func (s *darwinLaunchdService) Restart() error {
-	err := s.Stop()
-	if err != nil {
-		return err
-	}
-	time.Sleep(50 * time.Millisecond)
-	return s.Start()
+	return s.restart(s)
 }
 
 func (s *darwinLaunchdService) Run() error {
@@ -257,6 +257,8 @@ var launchdConfig = `<?xml version='1.0' encoding='UTF-8'?>
 {{if .UserName}}<key>UserName</key><string>{{html .UserName}}</string>{{end}}
 {{if .RootDir}}<key>RootDirectory</key><string>{{html .RootDir}}</string>{{end}}
 {{if .WorkingDir}}<key>WorkingDirectory</key><string>{{html .WorkingDir}}</string>{{end}}
+{{if .StdoutPath}}<key>StandardOutPath</key><string>{{html .StdoutPath}}</string>{{end}}
+{{if .StderrPath}}<key>StandardErrorPath</key><string>{{html .StderrPath}}</string>{{end}}
 <key>SessionCreate</key><{{bool .SessionCreate}}/>
 <key>KeepAlive</key><{{bool .KeepAlive}}/>
 <key>RunAtLoad</key><{{bool .RunAtLoad}}/>
@@ -264,8 +266,3 @@ var launchdConfig = `<?xml version='1.0' encoding='UTF-8'?>
 </dict>
 </plist>
 `
-
-// <key>StandardOutPath</key>
-// <string>/tmp/zlsgo/{{html .Name}}.log</string>
-// <key>StandardErrorPath</key>
-// <string>/tmp/zlsgo/{{html .Name}}.err</string>
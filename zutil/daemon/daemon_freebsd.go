@@ -211,7 +211,11 @@ func (s *freebsdRcdService) Restart() error {
 }
 
 func (s *freebsdRcdService) Run() error {
-	var err error
+	restore, err := s.redirectOutput()
+	defer restore()
+	if err != nil {
+		return err
+	}
 
 	err = s.i.Start(s)
 	if err != nil {
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"text/template"
 )
 
@@ -135,6 +137,8 @@ func (s *systemd) Install() error {
 	reloadSignal := ""
 	if v, ok := s.Options[optionReloadSignal]; ok {
 		reloadSignal, _ = v.(string)
+	} else if s.OnReload != nil {
+		reloadSignal = "HUP"
 	}
 	pidFile := ""
 	if v, ok := s.Options[optionPIDFile]; ok {
@@ -197,11 +201,40 @@ func (s *systemd) Run() (err error) {
 		runWait, _ = v.(func())
 	}
 
+	stopReload := s.watchReload()
 	runWait()
+	stopReload()
 
 	return s.i.Stop(s)
 }
 
+func (s *systemd) watchReload() (stop func()) {
+	if s.OnReload == nil {
+		return func() {}
+	}
+
+	sig := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sig, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-sig:
+				if err := s.OnReload(); err != nil {
+					s.logf("%s: reload aborted: %v", s.Name, err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
+
 func (s *systemd) Start() error {
 	if os.Getuid() == 0 {
 		return run("systemctl", "start", s.Name+".service")
@@ -218,12 +251,21 @@ func (s *systemd) Stop() error {
 	}
 }
 
+// Restart keep the atomic systemctl restart, RestartTimeout bounds how long it may block
 func (s *systemd) Restart() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.restartTimeout())
+	defer cancel()
+
+	var err error
 	if os.Getuid() == 0 {
-		return run("systemctl", "restart", s.Name+".service")
+		err = runContext(ctx, "systemctl", "restart", s.Name+".service")
 	} else {
-		return run("sudo", "-n", "systemctl", "restart", s.Name+".service")
+		err = runContext(ctx, "sudo", "-n", "systemctl", "restart", s.Name+".service")
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return ErrRestartTimeout
 	}
+	return err
 }
 
 func (s *systemd) Status() string {
@@ -252,6 +294,8 @@ ExecStart={{.Path|cmdEscape}}{{range .Arguments}} {{.|cmd}}{{end}}
 {{if .UserName}}User={{.UserName}}{{end}}
 {{if .ReloadSignal}}ExecReload=/bin/kill -{{.ReloadSignal}} "$MAINPID"{{end}}
 {{if .PIDFile}}PIDFile={{.PIDFile|cmd}}{{end}}
+{{if .Stdout}}StandardOutput=file:{{.Stdout|cmdEscape}}{{end}}
+{{if .Stderr}}StandardError={{if eq .Stderr "STDOUT"}}inherit{{else}}file:{{.Stderr|cmdEscape}}{{end}}{{end}}
 Restart=always
 RestartSec=120ms
 EnvironmentFile=-/etc/sysconfig/{{.Name}}
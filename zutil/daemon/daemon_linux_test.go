@@ -0,0 +1,85 @@
+package daemon
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sohaha/zlsgo"
+)
+
+func TestSystemdOutput(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	s := &systemd{}
+	render := func(c *Config) string {
+		var buf bytes.Buffer
+		tt.NoError(s.template().Execute(&buf, &struct {
+			*Config
+			Path         string
+			ReloadSignal string
+			PIDFile      string
+		}{Config: c, Path: "/usr/bin/app"}))
+		return buf.String()
+	}
+
+	out := render(&Config{Stdout: "/var/log/app.log", Stderr: "/var/log/app.err"})
+	tt.EqualTrue(strings.Contains(out, "StandardOutput=file:/var/log/app.log"))
+	tt.EqualTrue(strings.Contains(out, "StandardError=file:/var/log/app.err"))
+
+	out = render(&Config{Stdout: "/var/log/app.log", Stderr: "STDOUT"})
+	tt.EqualTrue(strings.Contains(out, "StandardError=inherit"))
+
+	out = render(&Config{})
+	tt.EqualTrue(!strings.Contains(out, "StandardOutput"))
+}
+
+func TestSystemdReload(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	var n int32
+	reloaded := make(chan int32, 3)
+	s := &systemd{Config: &Config{OnReload: func() error {
+		v := atomic.AddInt32(&n, 1)
+		reloaded <- v
+		if v == 2 {
+			return errors.New("bad config")
+		}
+		return nil
+	}}}
+
+	stop := s.watchReload()
+	defer stop()
+	for i := int32(1); i <= 3; i++ {
+		tt.NoError(syscall.Kill(os.Getpid(), syscall.SIGHUP))
+		select {
+		case v := <-reloaded:
+			tt.Equal(i, v)
+		case <-time.After(time.Second):
+			t.Fatal("reload not called")
+		}
+	}
+
+	stop = (&systemd{Config: &Config{}}).watchReload()
+	stop()
+}
+
+func TestRedirectOutputDescriptor(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	path := filepath.Join(t.TempDir(), "fd.log")
+	c := &Config{Stdout: path, Stderr: "STDOUT"}
+	restore, err := c.redirectOutput()
+	tt.NoError(err)
+	_, _ = syscall.Write(1, []byte("fd1;"))
+	_, _ = syscall.Write(2, []byte("fd2;"))
+	restore()
+
+	b, err := ioutil.ReadFile(path)
+	tt.NoError(err)
+	tt.Equal("fd1;fd2;", string(b))
+}
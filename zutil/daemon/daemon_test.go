@@ -1,8 +1,16 @@
 package daemon
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/sohaha/zlsgo"
 )
@@ -54,3 +62,173 @@ func TestUtil(t *testing.T) {
 	tt.Equal(IsPermissionError(ErrNotAnAdministrator), IsPermissionError(ErrNotAnRootUser))
 	_ = isSudo()
 }
+
+func TestRedirectOutput(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	dir := t.TempDir()
+
+	tt.Run("separate", func(tt *zlsgo.TestUtil) {
+		c := &Config{
+			Stdout: filepath.Join(dir, "logs", "out.log"),
+			Stderr: filepath.Join(dir, "logs", "err.log"),
+		}
+		restore, err := c.redirectOutput()
+		tt.NoError(err)
+		fmt.Fprint(os.Stdout, "stdout")
+		fmt.Fprint(os.Stderr, "stderr")
+		restore()
+
+		b, err := ioutil.ReadFile(c.Stdout)
+		tt.NoError(err)
+		tt.Equal("stdout", string(b))
+		b, err = ioutil.ReadFile(c.Stderr)
+		tt.NoError(err)
+		tt.Equal("stderr", string(b))
+	})
+
+	tt.Run("merge", func(tt *zlsgo.TestUtil) {
+		c := &Config{
+			Stdout: filepath.Join(dir, "merge.log"),
+			Stderr: "STDOUT",
+		}
+		restore, err := c.redirectOutput()
+		tt.NoError(err)
+		fmt.Fprint(os.Stdout, "out;")
+		fmt.Fprint(os.Stderr, "err;")
+		restore()
+
+		b, err := ioutil.ReadFile(c.Stdout)
+		tt.NoError(err)
+		tt.Equal("out;err;", string(b))
+		_, err = os.Stat(filepath.Join(dir, "STDOUT"))
+		tt.EqualTrue(os.IsNotExist(err))
+	})
+
+	tt.Run("none", func(tt *zlsgo.TestUtil) {
+		stdout := os.Stdout
+		restore, err := (&Config{}).redirectOutput()
+		tt.NoError(err)
+		tt.EqualTrue(os.Stdout == stdout)
+		restore()
+	})
+}
+
+type fakeService struct {
+	ServiceIface
+	stopAt  time.Time
+	started int
+	early   int
+}
+
+func (f *fakeService) Stop() error {
+	f.stopAt = time.Now().Add(300 * time.Millisecond)
+	return nil
+}
+
+func (f *fakeService) Start() error {
+	if time.Now().Before(f.stopAt) {
+		f.early++
+	}
+	f.started++
+	return nil
+}
+
+func (f *fakeService) Status() string {
+	if time.Now().Before(f.stopAt) {
+		return "Running"
+	}
+	return "Stop"
+}
+
+func TestRestart(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	tt.Run("wait", func(tt *zlsgo.TestUtil) {
+		f := &fakeService{}
+		c := &Config{RestartTimeout: time.Second}
+		tt.NoError(c.restart(f))
+		tt.Equal(1, f.started)
+		tt.Equal(0, f.early)
+	})
+
+	tt.Run("timeout", func(tt *zlsgo.TestUtil) {
+		f := &fakeService{}
+		c := &Config{RestartTimeout: 100 * time.Millisecond}
+		tt.Equal(ErrRestartTimeout, c.restart(f))
+		tt.Equal(0, f.started)
+	})
+
+	tt.Run("unknown", func(tt *zlsgo.TestUtil) {
+		for _, status := range []string{"Unknown", "1", "Access is denied.", ""} {
+			tt.EqualTrue(!isStopped(status))
+			status := status
+			tt.Equal(ErrRestartTimeout, waitStopped(func() string { return status }, 50*time.Millisecond))
+		}
+		tt.EqualTrue(isStopped("Stop"))
+	})
+
+	tt.Run("default", func(tt *zlsgo.TestUtil) {
+		tt.Equal(defaultRestartTimeout, (&Config{}).restartTimeout())
+		tt.Equal(time.Second, (&Config{RestartTimeout: time.Second}).restartTimeout())
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+	exe, _ := os.Executable()
+
+	tt.NoError((&Config{Name: "zlsgo_daemon_test", Executable: exe}).Validate())
+	tt.Equal(ErrNameFieldRequired, (&Config{Executable: exe}).Validate())
+	tt.EqualTrue((&Config{Name: "zlsgo_daemon_test", Executable: filepath.Join(t.TempDir(), "none")}).Validate() != nil)
+	tt.EqualTrue((&Config{Name: "zlsgo_daemon_test", Executable: exe, UserName: "zlsgo_no_such_user"}).Validate() != nil)
+	tt.EqualTrue((&Config{Name: "zlsgo_daemon_test", Executable: exe, WorkingDir: filepath.Join(t.TempDir(), "none")}).Validate() != nil)
+
+	if runtime.GOOS != "windows" {
+		file := filepath.Join(t.TempDir(), "exe")
+		_ = ioutil.WriteFile(file, []byte(""), 0644)
+		tt.EqualTrue((&Config{Name: "zlsgo_daemon_test", Executable: file}).Validate() != nil)
+	}
+}
+
+type defaultLogService struct {
+	*fakeService
+	w io.Writer
+}
+
+func (d *defaultLogService) logWriter() io.Writer {
+	return d.w
+}
+
+func TestLogger(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	var buf bytes.Buffer
+	c := &Config{Name: "zlsgo_daemon_test", Logger: &buf}
+	s := &loggedService{ServiceIface: &fakeService{}, c: c}
+	_, ok := ServiceIface(s).(Validator)
+	tt.EqualTrue(ok)
+	tt.NoError(s.Start())
+	tt.NoError(s.Stop())
+	tt.Equal("zlsgo_daemon_test: start\nzlsgo_daemon_test: stop\n", buf.String())
+
+	buf.Reset()
+	err := errors.New("denied")
+	tt.Equal(err, c.logResult("install", err))
+	tt.Equal("zlsgo_daemon_test: install failed: denied\n", buf.String())
+
+	var def bytes.Buffer
+	c = &Config{Name: "zlsgo_daemon_test"}
+	s = &loggedService{ServiceIface: &defaultLogService{fakeService: &fakeService{}, w: &def}, c: c}
+	tt.NoError(s.Start())
+	tt.Equal("zlsgo_daemon_test: start\n", def.String())
+	tt.EqualNil(c.Logger)
+
+	r, w, _ := os.Pipe()
+	stderr := os.Stderr
+	os.Stderr = w
+	(&Config{Name: "zlsgo_daemon_test"}).logf("to stderr")
+	os.Stderr = stderr
+	_ = w.Close()
+	b, _ := ioutil.ReadAll(r)
+	tt.Equal("to stderr\n", string(b))
+}
@@ -3,12 +3,15 @@ package daemon
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/sohaha/zlsgo/zshell"
+	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
 	"golang.org/x/sys/windows/svc"
 	"golang.org/x/sys/windows/svc/eventlog"
@@ -20,9 +23,16 @@ type (
 	windowsService struct {
 		i            Iface
 		stopStartErr error
+		eventLog     *eventLogWriter
 		*Config
 		errSync sync.Mutex
 	}
+	eventLogWriter struct {
+		l      *eventlog.Log
+		name   string
+		mu     sync.Mutex
+		closed bool
+	}
 )
 
 const version = "windows-service"
@@ -60,9 +70,83 @@ func (windowsSystem) New(i Iface, c *Config) (ServiceIface, error) {
 		Config: c,
 	}
 
+	// the event source only exists after Install, so it is opened on the first write
+	if v, ok := c.Options[optionEventLog]; ok {
+		if enable, _ := v.(bool); enable {
+			ws.eventLog = &eventLogWriter{name: c.Name}
+		}
+	}
+
 	return ws, nil
 }
 
+// NewEventLogWriter write to the windows event log source of the service,
+// the source is registered by Install
+func NewEventLogWriter(name string) (io.WriteCloser, error) {
+	w := &eventLogWriter{name: name}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *eventLogWriter) open() error {
+	if w.l != nil {
+		return nil
+	}
+	l, err := eventlog.Open(w.name)
+	if err != nil {
+		return err
+	}
+	w.l = l
+	return nil
+}
+
+// Write open the event log on first use, on failure the message goes to stderr with the error,
+// after Close messages go to stderr
+func (w *eventLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return os.Stderr.Write(p)
+	}
+	msg := strings.TrimSpace(string(p))
+	if err := w.open(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s (event log: %v)\n", msg, err)
+		return 0, err
+	}
+	if err := w.l.Info(1, msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *eventLogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	if w.l == nil {
+		return nil
+	}
+	err := w.l.Close()
+	w.l = nil
+	return err
+}
+
+// logWriter the event log when it is enabled, used while Config.Logger is nil
+func (w *windowsService) logWriter() io.Writer {
+	if w.eventLog == nil {
+		return nil
+	}
+	return w.eventLog
+}
+
+func (w *windowsService) closeEventLog() {
+	if w.eventLog != nil {
+		_ = w.eventLog.Close()
+	}
+}
+
 func (w *windowsService) String() string {
 	if len(w.DisplayName) > 0 {
 		return w.DisplayName
@@ -113,6 +197,26 @@ loop:
 	return false, 0
 }
 
+func (w *windowsService) serviceConfig() mgr.Config {
+	password := ""
+	if p, ok := w.Options["Password"]; ok {
+		password, _ = p.(string)
+	}
+	c := mgr.Config{
+		DisplayName:      w.DisplayName,
+		Description:      w.Description,
+		StartType:        mgr.StartAutomatic,
+		ServiceStartName: w.UserName,
+		Password:         password,
+	}
+	if v, ok := w.Options[optionInteractive]; ok {
+		if interactive, _ := v.(bool); interactive {
+			c.ServiceType = windows.SERVICE_WIN32_OWN_PROCESS | windows.SERVICE_INTERACTIVE_PROCESS
+		}
+	}
+	return c
+}
+
 func (w *windowsService) Install() error {
 	m, err := connect()
 	if err != nil {
@@ -125,17 +229,7 @@ func (w *windowsService) Install() error {
 		s.Close()
 		return fmt.Errorf("service %s already exists", w.Name)
 	}
-	password := ""
-	if p, ok := w.Options["Password"]; ok {
-		password, _ = p.(string)
-	}
-	s, err = m.CreateService(w.Name, exepath, mgr.Config{
-		DisplayName:      w.DisplayName,
-		Description:      w.Description,
-		StartType:        mgr.StartAutomatic,
-		ServiceStartName: w.UserName,
-		Password:         password,
-	}, w.Arguments...)
+	s, err = m.CreateService(w.Name, exepath, w.serviceConfig(), w.Arguments...)
 	if err != nil {
 		return err
 	}
@@ -178,6 +272,7 @@ func (w *windowsService) Uninstall() error {
 		return err
 	}
 
+	w.closeEventLog()
 	err = eventlog.Remove(w.Name)
 	if err != nil {
 		return fmt.Errorf("removeEventLogSource() failed: %s", err)
@@ -187,8 +282,14 @@ func (w *windowsService) Uninstall() error {
 }
 
 func (w *windowsService) Run() error {
+	defer w.closeEventLog()
 	w.setError(nil)
 	if !interactive {
+		restore, err := w.redirectOutput()
+		defer restore()
+		if err != nil {
+			return err
+		}
 		runErr := svc.Run(w.Name, w)
 		startStopErr := w.getError()
 		if startStopErr != nil {
@@ -262,11 +363,7 @@ func (w *windowsService) Stop() error {
 }
 
 func (w *windowsService) Restart() error {
-	err := w.Stop()
-	if err != nil {
-		return err
-	}
-	return w.Start()
+	return w.restart(w)
 }
 
 func (w *windowsService) Status() string {
@@ -280,6 +377,10 @@ func (w *windowsService) Status() string {
 		return err.Error()
 	}
 	defer s.Close()
+	return queryStatus(s)
+}
+
+func queryStatus(s *mgr.Service) string {
 	q, err := s.Query()
 	if err != nil {
 		return err.Error()
@@ -314,23 +415,15 @@ func (w *windowsService) stopWait(s *mgr.Service) error {
 		_ = w.forceKeep(status.ProcessId)
 	}
 
-	timeDuration := time.Millisecond * 100
-	timeout := time.After(getStopTimeout() + (timeDuration * 2))
-	tick := time.NewTicker(timeDuration)
-	defer tick.Stop()
-	for status.State != svc.Stopped {
-		select {
-		case <-tick.C:
-			status, err = s.Query()
-			if err != nil {
-				return err
-			}
-		case <-timeout:
-			_ = w.forceKeep(status.ProcessId)
-			break
-		}
+	query := func() string {
+		return queryStatus(s)
 	}
-	return nil
+	err = waitStopped(query, getStopTimeout()+time.Millisecond*200)
+	if err == ErrRestartTimeout {
+		_ = w.forceKeep(status.ProcessId)
+		err = waitStopped(query, time.Second*5)
+	}
+	return err
 }
 
 func connect() (*mgr.Mgr, error) {
@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/sohaha/zlsgo"
+	"golang.org/x/sys/windows"
+)
+
+func TestServiceConfigInteractive(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	w := &windowsService{Config: &Config{Name: "zlsgo_daemon_test"}}
+	tt.Equal(uint32(0), w.serviceConfig().ServiceType&windows.SERVICE_INTERACTIVE_PROCESS)
+
+	w.Options = map[string]interface{}{optionInteractive: true}
+	c := w.serviceConfig()
+	tt.EqualTrue(c.ServiceType&windows.SERVICE_INTERACTIVE_PROCESS != 0)
+	tt.EqualTrue(c.ServiceType&windows.SERVICE_WIN32_OWN_PROCESS != 0)
+
+	w.Options[optionInteractive] = false
+	tt.Equal(uint32(0), w.serviceConfig().ServiceType&windows.SERVICE_INTERACTIVE_PROCESS)
+}
+
+func TestEventLogWriter(t *testing.T) {
+	tt := zlsgo.NewTest(t)
+
+	w, err := NewEventLogWriter("zlsgo_daemon_test")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer w.Close()
+
+	c := &Config{Name: "zlsgo_daemon_test", Logger: w}
+	tt.NoError(c.logResult("start", nil))
+}
@@ -0,0 +1,30 @@
+//go:build !windows
+// +build !windows
+
+package daemon
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// redirectStd point the process descriptor of stdout or stderr at f
+func redirectStd(f *os.File, stderr bool) (restore func(), err error) {
+	fd := unix.Stdout
+	if stderr {
+		fd = unix.Stderr
+	}
+	saved, err := unix.Dup(fd)
+	if err != nil {
+		return func() {}, err
+	}
+	if err = unix.Dup2(int(f.Fd()), fd); err != nil {
+		_ = unix.Close(saved)
+		return func() {}, err
+	}
+	return func() {
+		_ = unix.Dup2(saved, fd)
+		_ = unix.Close(saved)
+	}, nil
+}
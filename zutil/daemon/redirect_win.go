@@ -0,0 +1,28 @@
+//go:build windows
+// +build windows
+
+package daemon
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// redirectStd point the process standard output or error handle at f
+func redirectStd(f *os.File, stderr bool) (restore func(), err error) {
+	std := uint32(windows.STD_OUTPUT_HANDLE)
+	if stderr {
+		std = uint32(windows.STD_ERROR_HANDLE)
+	}
+	saved, err := windows.GetStdHandle(std)
+	if err != nil {
+		return func() {}, err
+	}
+	if err = windows.SetStdHandle(std, windows.Handle(f.Fd())); err != nil {
+		return func() {}, err
+	}
+	return func() {
+		_ = windows.SetStdHandle(std, saved)
+	}, nil
+}
@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"os/user"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/sohaha/zlsgo/zshell"
 	"github.com/sohaha/zlsgo/ztype"
@@ -21,18 +26,246 @@ func (c *Config) execPath() (path string) {
 	return
 }
 
+// Validate check that the name is set, the executable is runnable,
+// the working directory exists and the user exists on the system
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return ErrNameFieldRequired
+	}
+
+	path := c.execPath()
+	if path == "" {
+		return errors.New("executable path is empty")
+	}
+	f, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("executable %s: %w", path, err)
+	}
+	if f.IsDir() {
+		return fmt.Errorf("executable %s is a directory", path)
+	}
+	if runtime.GOOS != "windows" && f.Mode()&0111 == 0 {
+		return fmt.Errorf("executable %s is not runnable", path)
+	}
+
+	if c.WorkingDir != "" {
+		if f, err = os.Stat(c.WorkingDir); err != nil || !f.IsDir() {
+			return fmt.Errorf("working directory %s does not exist", c.WorkingDir)
+		}
+	}
+
+	if c.UserName != "" {
+		if _, err = user.Lookup(c.UserName); err != nil {
+			return fmt.Errorf("user %s: %w", c.UserName, err)
+		}
+	}
+
+	return nil
+}
+
+type loggedService struct {
+	ServiceIface
+	c *Config
+}
+
+func (s *loggedService) Validate() error {
+	return s.c.Validate()
+}
+
+func (s *loggedService) Install() error {
+	return s.logResult("install", s.ServiceIface.Install())
+}
+
+func (s *loggedService) Uninstall() error {
+	return s.logResult("uninstall", s.ServiceIface.Uninstall())
+}
+
+func (s *loggedService) Start() error {
+	return s.logResult("start", s.ServiceIface.Start())
+}
+
+func (s *loggedService) Stop() error {
+	return s.logResult("stop", s.ServiceIface.Stop())
+}
+
+func (s *loggedService) Restart() error {
+	return s.logResult("restart", s.ServiceIface.Restart())
+}
+
+// logResult log to Config.Logger, or to the back end default when it is nil
+func (s *loggedService) logResult(action string, err error) error {
+	w := s.c.Logger
+	if w == nil {
+		if l, ok := s.ServiceIface.(logWriter); ok {
+			w = l.logWriter()
+		}
+	}
+	return logResult(w, s.c.Name, action, err)
+}
+
+// logWriter implemented by back ends that log somewhere other than stderr by default
+type logWriter interface {
+	logWriter() io.Writer
+}
+
+func (c *Config) logf(format string, v ...interface{}) {
+	logf(c.Logger, format, v...)
+}
+
+func (c *Config) logResult(action string, err error) error {
+	return logResult(c.Logger, c.Name, action, err)
+}
+
+func logf(w io.Writer, format string, v ...interface{}) {
+	if w == nil {
+		w = os.Stderr
+	}
+	_, _ = fmt.Fprintf(w, format+"\n", v...)
+}
+
+func logResult(w io.Writer, name, action string, err error) error {
+	if err != nil {
+		logf(w, "%s: %s failed: %v", name, action, err)
+	} else {
+		logf(w, "%s: %s", name, action)
+	}
+	return err
+}
+
+func (c *Config) outputPaths() (stdout, stderr string) {
+	stdout, stderr = c.Stdout, c.Stderr
+	if stderr == stderrToStdout {
+		stderr = stdout
+	}
+	return
+}
+
+func openOutputFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+}
+
+// redirectOutput redirect the stdout and stderr of the current process to the configured files,
+// both os.Stdout/os.Stderr and the underlying descriptors are replaced so child processes inherit them
+func (c *Config) redirectOutput() (restore func(), err error) {
+	stdout, stderr := os.Stdout, os.Stderr
+	var (
+		files    []*os.File
+		restores []func()
+	)
+	restore = func() {
+		for i := len(restores) - 1; i >= 0; i-- {
+			restores[i]()
+		}
+		os.Stdout, os.Stderr = stdout, stderr
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}
+	redirect := func(f *os.File, isStderr bool) error {
+		r, err := redirectStd(f, isStderr)
+		if err != nil {
+			return err
+		}
+		restores = append(restores, r)
+		if isStderr {
+			os.Stderr = f
+		} else {
+			os.Stdout = f
+		}
+		return nil
+	}
+
+	outPath, errPath := c.outputPaths()
+	var out *os.File
+	if outPath != "" {
+		if out, err = openOutputFile(outPath); err != nil {
+			return restore, err
+		}
+		files = append(files, out)
+		if err = redirect(out, false); err != nil {
+			return restore, err
+		}
+	}
+
+	if errPath != "" {
+		f := out
+		if errPath != outPath {
+			if f, err = openOutputFile(errPath); err != nil {
+				return restore, err
+			}
+			files = append(files, f)
+		}
+		if err = redirect(f, true); err != nil {
+			return restore, err
+		}
+	}
+
+	return restore, nil
+}
+
+// restart stop the service, wait until it is fully stopped and then start it again,
+// only for back ends whose Stop returns before the service has exited
+func (c *Config) restart(s ServiceIface) error {
+	err := s.Stop()
+	if err != nil {
+		return err
+	}
+
+	if err = waitStopped(s.Status, c.restartTimeout()); err != nil {
+		return err
+	}
+
+	return s.Start()
+}
+
+func (c *Config) restartTimeout() time.Duration {
+	if c.RestartTimeout <= 0 {
+		return defaultRestartTimeout
+	}
+	return c.RestartTimeout
+}
+
+// waitStopped poll status until the service reports stopped or timeout passes
+func waitStopped(status func() string, timeout time.Duration) error {
+	if isStopped(status()) {
+		return nil
+	}
+
+	deadline := time.After(timeout)
+	tick := time.NewTicker(time.Millisecond * 100)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			if isStopped(status()) {
+				return nil
+			}
+		case <-deadline:
+			return ErrRestartTimeout
+		}
+	}
+}
+
+// isStopped only "Stop" counts, unknown states and query errors are not treated as stopped
+func isStopped(status string) bool {
+	return status == "Stop"
+}
+
 func runGrep(grep, command string, args ...string) (res string, err error) {
 	var grepout bytes.Buffer
 	var out bytes.Buffer
 	var outErr bytes.Buffer
 	commands := []string{command}
 	commands = append(commands, args...)
-	err = runcmd(commands, bytes.NewReader([]byte("")), &out, &outErr)
+	err = runcmd(context.Background(), commands, bytes.NewReader([]byte("")), &out, &outErr)
 	if err != nil {
 		return
 	}
 	commands = []string{"grep", grep}
-	err = runcmd(commands, bytes.NewReader(out.Bytes()), &grepout, &outErr)
+	err = runcmd(context.Background(), commands, bytes.NewReader(out.Bytes()), &grepout, &outErr)
 	if err != nil {
 		return
 	}
@@ -57,15 +290,19 @@ func IsPermissionError(err error) bool {
 }
 
 func run(command string, args ...string) error {
+	return runContext(context.Background(), command, args...)
+}
+
+func runContext(ctx context.Context, command string, args ...string) error {
 	var out bytes.Buffer
 	var outErr bytes.Buffer
 	commands := []string{command}
 	commands = append(commands, args...)
-	return runcmd(commands, bytes.NewReader([]byte("")), &out, &outErr)
+	return runcmd(ctx, commands, bytes.NewReader([]byte("")), &out, &outErr)
 }
 
-func runcmd(commands []string, in *bytes.Reader, out, outErr *bytes.Buffer) error {
-	code, _, _, err := zshell.ExecCommand(context.Background(), commands, in, out, outErr)
+func runcmd(ctx context.Context, commands []string, in *bytes.Reader, out, outErr *bytes.Buffer) error {
+	code, _, _, err := zshell.ExecCommand(ctx, commands, in, out, outErr)
 	if err != nil {
 		return err
 	}